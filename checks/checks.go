@@ -0,0 +1,42 @@
+// Package checks is the public extension point for custom check types.
+// Go programs embedding regrada (and compiled-in plugins of the CLI)
+// register evaluators here; suites then reference them by name like any
+// built-in check:
+//
+//	func init() {
+//		checks.Register("grounded", checks.Correctness, func(ctx *checks.Context, arg string) checks.Result {
+//			...
+//		})
+//	}
+//
+// Registration must happen before the suite runs, which init functions
+// guarantee. The CLI picks up anything registered by packages compiled
+// into the binary — see cmd/regrada/plugins.go.
+package checks
+
+import "github.com/regrada-ai/regrada/internal/evals"
+
+// Context carries what a check may inspect: the test, its resolved
+// prompt and the model response.
+type Context = evals.CheckContext
+
+// Result is the outcome of a single check.
+type Result = evals.CheckResult
+
+// Func evaluates one check; arg is the text after the first colon in
+// the check spec.
+type Func = evals.CheckFunc
+
+// Failure categories a check registers under.
+const (
+	Correctness = evals.CategoryCorrectness
+	Safety      = evals.CategorySafety
+	Cost        = evals.CategoryCost
+	Latency     = evals.CategoryLatency
+)
+
+// Register adds a named check with a failure category. Registering a
+// name twice panics, so plugins cannot silently shadow built-ins.
+func Register(name, category string, fn Func) {
+	evals.RegisterCheck(name, category, fn)
+}
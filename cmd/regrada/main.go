@@ -0,0 +1,15 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/regrada-ai/regrada/internal/cli"
+)
+
+func main() {
+	if err := cli.Main(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "regrada: %v\n", err)
+		os.Exit(cli.ExitCode(err))
+	}
+}
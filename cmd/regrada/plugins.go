@@ -0,0 +1,9 @@
+package main
+
+// Compiled-in check plugins: blank-import packages that call
+// checks.Register from init and rebuild the binary. Anything they
+// register is discovered automatically — no further wiring needed.
+//
+//	import (
+//		_ "example.com/myorg/regrada-checks"
+//	)
@@ -0,0 +1,11 @@
+module github.com/regrada-ai/regrada
+
+go 1.21.6
+
+require (
+	github.com/gorilla/websocket v1.5.1
+	github.com/tetratelabs/wazero v1.6.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/net v0.17.0 // indirect
@@ -0,0 +1,34 @@
+// Package hooks is the public extension point for lifecycle events.
+// Go programs embedding regrada (and compiled-in plugins of the CLI)
+// register callbacks here and receive every event with its JSON-ready
+// payload:
+//
+//	func init() {
+//		hooks.Register(func(event hooks.Event, payload any) {
+//			if event == hooks.RegressionDetected {
+//				...
+//			}
+//		})
+//	}
+//
+// Shell-command hooks are configured in .regrada.yaml instead, under
+// the hooks: section.
+package hooks
+
+import "github.com/regrada-ai/regrada/internal/hooks"
+
+// Event names one lifecycle moment.
+type Event = hooks.Event
+
+// The events a callback can receive.
+const (
+	RunStarted         = hooks.EventRunStarted
+	TestFinished       = hooks.EventTestFinished
+	RegressionDetected = hooks.EventRegressionDetected
+	BaselineSaved      = hooks.EventBaselineSaved
+)
+
+// Register adds an in-process callback fired on every event.
+func Register(fn func(Event, any)) {
+	hooks.RegisterCallback(fn)
+}
@@ -0,0 +1,74 @@
+// Package baseline reads and writes the recorded known-good state that
+// new runs are compared against.
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/atomicfile"
+	"github.com/regrada-ai/regrada/internal/evals"
+)
+
+// CurrentVersion is the baseline schema written by this regrada.
+const CurrentVersion = 1
+
+// Baseline is the persisted known-good test state.
+type Baseline struct {
+	Version    int             `json:"version"`
+	RecordedAt time.Time       `json:"recorded_at"`
+	Tests      map[string]Test `json:"tests"`
+}
+
+// Test is the recorded state of one test case.
+type Test struct {
+	Status     string    `json:"status"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// New returns an empty baseline.
+func New() *Baseline {
+	return &Baseline{Version: CurrentVersion, Tests: map[string]Test{}}
+}
+
+// Load reads the baseline at path. A missing file is reported with
+// os.ErrNotExist so callers can distinguish "no baseline yet" from
+// corruption.
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if b.Version > CurrentVersion {
+		return nil, fmt.Errorf("%s uses baseline schema v%d; this regrada understands up to v%d — upgrade regrada", path, b.Version, CurrentVersion)
+	}
+	if b.Tests == nil {
+		b.Tests = map[string]Test{}
+	}
+	return &b, nil
+}
+
+// Save writes the baseline to path, creating parent directories.
+func (b *Baseline) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Record stores the outcome of one test, replacing any previous entry.
+func (b *Baseline) Record(tr evals.TestResult) {
+	b.Tests[tr.Name] = Test{Status: tr.Status, RecordedAt: time.Now().UTC()}
+	b.RecordedAt = time.Now().UTC()
+}
@@ -0,0 +1,33 @@
+// Package browser opens URLs and files in the user's default browser.
+package browser
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// Open launches the default browser on target, which may be a URL or a
+// local file path. Setting REGRADA_NO_BROWSER disables launching, which
+// keeps CI runs and tests from spawning windows; the target is printed
+// instead.
+func Open(target string) error {
+	if os.Getenv("REGRADA_NO_BROWSER") != "" {
+		fmt.Println(target)
+		return nil
+	}
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	default:
+		cmd = exec.Command("xdg-open", target)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("opening browser: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Package chatops posts behavioral-diff summaries to chat and collects
+// approve/reject decisions over a small callback server, so a reviewer
+// can accept an intended change without leaving Slack.
+package chatops
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Diff summarizes the behavioral change a reviewer is asked to judge.
+type Diff struct {
+	Project     string
+	RunID       string
+	Score       float64
+	Regressions []string
+}
+
+// PostDiff sends the diff to a Slack incoming webhook as an interactive
+// message whose Approve/Reject buttons link to the callback server.
+func PostDiff(webhook string, d Diff, approveURL, rejectURL string) error {
+	lines := make([]string, 0, len(d.Regressions))
+	for _, name := range d.Regressions {
+		lines = append(lines, "• "+name)
+	}
+	title := fmt.Sprintf("*regrada* detected %d regression(s) in run %s (score %.2f)", len(d.Regressions), d.RunID, d.Score)
+	if d.Project != "" {
+		title = fmt.Sprintf("*regrada* detected %d regression(s) in %s, run %s (score %.2f)", len(d.Regressions), d.Project, d.RunID, d.Score)
+	}
+	payload := map[string]any{
+		"blocks": []map[string]any{
+			{
+				"type": "section",
+				"text": map[string]any{"type": "mrkdwn", "text": title + "\n" + strings.Join(lines, "\n")},
+			},
+			{
+				"type": "actions",
+				"elements": []map[string]any{
+					{
+						"type":  "button",
+						"text":  map[string]any{"type": "plain_text", "text": "Approve as new baseline"},
+						"style": "primary",
+						"url":   approveURL,
+					},
+					{
+						"type":  "button",
+						"text":  map[string]any{"type": "plain_text", "text": "Reject"},
+						"style": "danger",
+						"url":   rejectURL,
+					},
+				},
+			},
+		},
+	}
+	return post(webhook, payload)
+}
+
+// Summary is a non-interactive notification about a failing run or
+// gate check: no buttons, just the facts a channel needs to triage.
+type Summary struct {
+	Project string
+	Branch  string
+	RunID   string
+	// Verdict is the headline, e.g. "3 tests failed" or "2 gate
+	// violations".
+	Verdict string
+	// Lines are the individual findings: regressed tests, gate
+	// violations, and the like.
+	Lines []string
+	// CostDeltaUSD is the estimated cost change against the previous
+	// session; zero when unknown.
+	CostDeltaUSD float64
+}
+
+// PostSummary sends the summary to a Slack incoming webhook as a plain
+// message.
+func PostSummary(webhook string, s Summary) error {
+	title := fmt.Sprintf("*regrada*: %s in run %s", s.Verdict, s.RunID)
+	if s.Project != "" {
+		title = fmt.Sprintf("*regrada*: %s in %s, run %s", s.Verdict, s.Project, s.RunID)
+	}
+	if s.Branch != "" {
+		title += fmt.Sprintf(" (branch %s)", s.Branch)
+	}
+	text := title
+	for _, line := range s.Lines {
+		text += "\n• " + line
+	}
+	if s.CostDeltaUSD != 0 {
+		text += fmt.Sprintf("\ncost delta vs previous session: %+.4f USD", s.CostDeltaUSD)
+	}
+	payload := map[string]any{
+		"blocks": []map[string]any{
+			{
+				"type": "section",
+				"text": map[string]any{"type": "mrkdwn", "text": text},
+			},
+		},
+	}
+	return post(webhook, payload)
+}
+
+// post marshals the payload and delivers it to the incoming webhook.
+func post(webhook string, payload map[string]any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := http.Post(webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("posting to Slack: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// AwaitDecision serves /approve and /reject on addr until a reviewer
+// clicks one of them or the timeout elapses. Timing out counts as a
+// rejection so an unattended run cannot self-approve.
+func AwaitDecision(addr string, timeout time.Duration) (bool, error) {
+	decision := make(chan bool, 1)
+	mux := http.NewServeMux()
+	record := func(approved bool, msg string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case decision <- approved:
+			default:
+			}
+			fmt.Fprintln(w, msg)
+		}
+	}
+	mux.Handle("/approve", record(true, "Approved: the run will be promoted to the baseline."))
+	mux.Handle("/reject", record(false, "Rejected: the run keeps failing the gate."))
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return false, fmt.Errorf("starting callback server: %w", err)
+	}
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(ln) //nolint:errcheck // shut down below
+	defer srv.Close()
+
+	select {
+	case approved := <-decision:
+		return approved, nil
+	case <-time.After(timeout):
+		return false, fmt.Errorf("no approval decision within %s", timeout)
+	}
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/regrada-ai/regrada/internal/config"
+	"github.com/regrada-ai/regrada/internal/evals"
+	"github.com/regrada-ai/regrada/internal/store"
+	"github.com/regrada-ai/regrada/internal/upload"
+)
+
+var artifactsCmd = &Command{
+	Name:    "artifacts",
+	Summary: "Push run artifacts to the configured destination",
+	Usage:   "push [--dest url] [--run run-id]",
+}
+
+func init() {
+	artifactsCmd.Run = runArtifacts
+	register(artifactsCmd)
+}
+
+func runArtifacts(ctx *Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(artifactsCmd, "subcommand expected")
+	}
+	switch args[0] {
+	case "push":
+		return runArtifactsPush(ctx, args[1:])
+	default:
+		return usageError(artifactsCmd, "unknown subcommand %q", args[0])
+	}
+}
+
+// runArtifactsPush uploads the results file, the matching HTML report
+// and any recorded sessions under a per-run prefix, printing the stable
+// URL of each object for use in PR comments.
+func runArtifactsPush(ctx *Context, args []string) error {
+	fs := newFlagSet(artifactsCmd)
+	destFlag := fs.String("dest", "", "destination URL (default: artifacts.destination from config)")
+	runFlag := fs.String("run", "", "run id to push (default: latest)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := ctx.Config()
+	if err != nil {
+		return err
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+
+	dest := *destFlag
+	if dest == "" {
+		dest = cfg.Artifacts.Destination
+	}
+	if dest == "" {
+		return fmt.Errorf("no destination: pass --dest or set artifacts.destination in %s", config.FileName)
+	}
+	up, err := upload.New(dest)
+	if err != nil {
+		return err
+	}
+
+	files, runID, err := collectArtifacts(st, *runFlag)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no artifacts to push; run the evals first")
+	}
+
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		key := path.Join(runID, filepath.Base(f))
+		url, err := up.Put(key, data, upload.ContentType(f))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  %s -> %s\n", filepath.Base(f), url)
+	}
+	fmt.Printf("Pushed %d artifacts for run %s\n", len(files), runID)
+	return nil
+}
+
+// collectArtifacts gathers the files for a run and resolves its id.
+func collectArtifacts(st *store.Store, runID string) ([]string, string, error) {
+	var files []string
+	if fileExists(st.ResultsPath()) {
+		res, err := evals.LoadResults(st.ResultsPath())
+		if err != nil {
+			return nil, "", err
+		}
+		if runID == "" {
+			runID = res.RunID
+		}
+		if res.RunID == runID {
+			files = append(files, st.ResultsPath())
+		}
+	}
+	if runID == "" {
+		return nil, "", fmt.Errorf("no run id: pass --run or run the evals first")
+	}
+	if report := filepath.Join(st.ReportsDir(), runID+".html"); fileExists(report) {
+		files = append(files, report)
+	}
+	sessions, err := filepath.Glob(filepath.Join(st.SessionsDir(), "*.json"))
+	if err != nil {
+		return nil, "", err
+	}
+	files = append(files, sessions...)
+	return files, runID, nil
+}
@@ -0,0 +1,324 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/regrada-ai/regrada/internal/baseline"
+	"github.com/regrada-ai/regrada/internal/evals"
+	"github.com/regrada-ai/regrada/internal/hooks"
+	"github.com/regrada-ai/regrada/internal/store"
+)
+
+var baselineCmd = &Command{
+	Name:    "baseline",
+	Summary: "Record and manage the known-good baseline",
+	Usage:   "update [--tests name,...] | promote [run-id] | list | show | save | delete [run-id]",
+}
+
+func init() {
+	baselineCmd.Run = runBaseline
+	register(baselineCmd)
+}
+
+func runBaseline(ctx *Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(baselineCmd, "subcommand expected")
+	}
+	switch args[0] {
+	case "update":
+		return runBaselineUpdate(ctx, args[1:])
+	case "promote":
+		return runBaselinePromote(ctx, args[1:])
+	case "list":
+		return runBaselineList(ctx, args[1:])
+	case "show":
+		return runBaselineShow(ctx, args[1:])
+	case "save":
+		return runBaselineSave(ctx, args[1:])
+	case "delete":
+		return runBaselineDelete(ctx, args[1:])
+	default:
+		return usageError(baselineCmd, "unknown subcommand %q", args[0])
+	}
+}
+
+// runBaselinePromote copies a run's full eval results into the
+// results baseline, keeping it separate from the status baseline so
+// the two formats are never conflated.
+func runBaselinePromote(ctx *Context, args []string) error {
+	fs := newFlagSet(baselineCmd)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return usageError(baselineCmd, "at most one run id expected")
+	}
+	cfg, err := ctx.Config()
+	if err != nil {
+		return err
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	path := st.ResultsPath()
+	if fs.NArg() == 1 {
+		path = filepath.Join(st.HistoryDir(), fs.Arg(0)+".json")
+	}
+	res, err := evals.LoadResults(path)
+	if err != nil {
+		return err
+	}
+	if err := archiveCurrentBaseline(st); err != nil {
+		return err
+	}
+	if err := res.Save(st.ResultsBaselinePath()); err != nil {
+		return err
+	}
+	// Also store under the env/branch key so other branches keep their
+	// own baselines and new feature branches fall back to the default
+	// branch's.
+	if key := baselineKey(cfg); key != "" {
+		if err := st.EnsureDir(st.BaselinesDir()); err != nil {
+			return err
+		}
+		keyed := filepath.Join(st.BaselinesDir(), key+".json")
+		if err := res.Save(keyed); err != nil {
+			return err
+		}
+		fmt.Printf("  baseline for %s: %s\n", key, keyed)
+		pushBaseline(cfg, st, keyed)
+	}
+	pushBaseline(cfg, st, st.ResultsBaselinePath())
+	hooks.New(cfg.Hooks, cfg.Root).Fire(hooks.EventBaselineSaved,
+		map[string]any{"path": st.ResultsBaselinePath(), "run_id": res.RunID, "tests": len(res.Tests)})
+	fmt.Printf("Promoted run %s (%d tests, score %.2f) to %s\n",
+		res.RunID, len(res.Tests), res.Score, st.ResultsBaselinePath())
+	return nil
+}
+
+// runBaselineUpdate re-records test outcomes into the baseline. With
+// --tests only the named tests are touched, so accepting one intended
+// change does not silently re-baseline everything else.
+func runBaselineUpdate(ctx *Context, args []string) error {
+	fs := newFlagSet(baselineCmd)
+	testsFlag := fs.String("tests", "", "comma-separated test names to update (default: all)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError(baselineCmd, "unexpected argument %q", fs.Arg(0))
+	}
+
+	cfg, err := ctx.Config()
+	if err != nil {
+		return err
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	if *testsFlag != "" {
+		for _, n := range strings.Split(*testsFlag, ",") {
+			if n = strings.TrimSpace(n); n != "" {
+				names = append(names, n)
+			}
+		}
+	}
+
+	b, err := baseline.Load(st.BaselinePath())
+	if os.IsNotExist(err) {
+		if len(names) > 0 {
+			return fmt.Errorf("no baseline at %s; record a full one first with `regrada baseline update`", st.BaselinePath())
+		}
+		b = baseline.New()
+	} else if err != nil {
+		return err
+	}
+
+	suite, err := evals.LoadSuite(cfg.SuitePath())
+	if err != nil {
+		return err
+	}
+	res, err := evals.NewRunner(suite).Run(names)
+	if err != nil {
+		return err
+	}
+	for _, tr := range res.Tests {
+		b.Record(tr)
+		fmt.Printf("  %s: %s\n", tr.Name, tr.Status)
+	}
+	if err := b.Save(st.BaselinePath()); err != nil {
+		return err
+	}
+	pushBaseline(cfg, st, st.BaselinePath())
+	hooks.New(cfg.Hooks, cfg.Root).Fire(hooks.EventBaselineSaved,
+		map[string]any{"path": st.BaselinePath(), "tests": len(res.Tests)})
+	fmt.Printf("Updated baseline (%d of %d tests) at %s\n", len(res.Tests), len(b.Tests), st.BaselinePath())
+	return nil
+}
+
+// archiveCurrentBaseline copies the current results baseline, when one
+// exists, into the baselines directory under its run id, so promotions
+// keep a history instead of overwriting the only copy.
+func archiveCurrentBaseline(st *store.Store) error {
+	if !fileExists(st.ResultsBaselinePath()) {
+		return nil
+	}
+	prev, err := evals.LoadResults(st.ResultsBaselinePath())
+	if err != nil {
+		return err
+	}
+	if err := st.EnsureDir(st.BaselinesDir()); err != nil {
+		return err
+	}
+	return prev.Save(filepath.Join(st.BaselinesDir(), prev.RunID+".json"))
+}
+
+// runBaselineList prints the current results baseline and every
+// archived one, newest first.
+func runBaselineList(ctx *Context, args []string) error {
+	fs := newFlagSet(baselineCmd)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	if fileExists(st.ResultsBaselinePath()) {
+		res, err := evals.LoadResults(st.ResultsBaselinePath())
+		if err != nil {
+			return err
+		}
+		passed, failed := res.Counts()
+		fmt.Printf("* %s  %s  score %.2f  %d passed, %d failed (current)\n",
+			res.RunID, res.StartedAt.Format("2006-01-02 15:04"), res.Score, passed, failed)
+	}
+	matches, err := filepath.Glob(filepath.Join(st.BaselinesDir(), "*.json"))
+	if err != nil {
+		return err
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(matches)))
+	shown := 0
+	for _, path := range matches {
+		res, err := evals.LoadResults(path)
+		if err != nil {
+			return err
+		}
+		passed, failed := res.Counts()
+		label := ""
+		if key := strings.TrimSuffix(filepath.Base(path), ".json"); key != res.RunID {
+			label = "  [" + key + "]"
+		}
+		fmt.Printf("  %s  %s  score %.2f  %d passed, %d failed%s\n",
+			res.RunID, res.StartedAt.Format("2006-01-02 15:04"), res.Score, passed, failed, label)
+		shown++
+	}
+	if shown == 0 && !fileExists(st.ResultsBaselinePath()) {
+		fmt.Println("No baselines; promote a run with `regrada baseline promote`.")
+	}
+	return nil
+}
+
+// runBaselineShow prints what the current baseline contains: the
+// promoted results baseline when one exists, otherwise the per-test
+// statuses of the status baseline.
+func runBaselineShow(ctx *Context, args []string) error {
+	fs := newFlagSet(baselineCmd)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	if fileExists(st.ResultsBaselinePath()) {
+		res, err := evals.LoadResults(st.ResultsBaselinePath())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Results baseline: run %s, promoted from %s, score %.2f over %d tests\n",
+			res.RunID, res.StartedAt.Format("2006-01-02 15:04"), res.Score, len(res.Tests))
+		for _, tr := range res.Tests {
+			fmt.Printf("  %-40s %s (score %.2f)\n", tr.Name, tr.Status, tr.Score)
+		}
+		return nil
+	}
+	b, err := baseline.Load(st.BaselinePath())
+	if os.IsNotExist(err) {
+		return fmt.Errorf("no baseline at %s", st.ResultsBaselinePath())
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Status baseline: %d tests recorded %s\n", len(b.Tests), b.RecordedAt.Format("2006-01-02 15:04"))
+	var names []string
+	for name := range b.Tests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("  %-40s %s\n", name, b.Tests[name].Status)
+	}
+	return nil
+}
+
+// runBaselineSave archives the current results baseline without
+// replacing it, an explicit snapshot before risky suite surgery.
+func runBaselineSave(ctx *Context, args []string) error {
+	fs := newFlagSet(baselineCmd)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	if !fileExists(st.ResultsBaselinePath()) {
+		return fmt.Errorf("no results baseline to save; promote a run first")
+	}
+	res, err := evals.LoadResults(st.ResultsBaselinePath())
+	if err != nil {
+		return err
+	}
+	if err := archiveCurrentBaseline(st); err != nil {
+		return err
+	}
+	fmt.Printf("Saved baseline %s to %s\n", res.RunID, filepath.Join(st.BaselinesDir(), res.RunID+".json"))
+	return nil
+}
+
+// runBaselineDelete removes an archived baseline by run id, or the
+// current results baseline when no id is given.
+func runBaselineDelete(ctx *Context, args []string) error {
+	fs := newFlagSet(baselineCmd)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return usageError(baselineCmd, "at most one run id expected")
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	path := st.ResultsBaselinePath()
+	if fs.NArg() == 1 {
+		path = filepath.Join(st.BaselinesDir(), fs.Arg(0)+".json")
+	}
+	if !fileExists(path) {
+		return fmt.Errorf("no baseline at %s", path)
+	}
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	fmt.Printf("Deleted %s\n", path)
+	return nil
+}
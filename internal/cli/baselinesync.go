@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/regrada-ai/regrada/internal/atomicfile"
+	"github.com/regrada-ai/regrada/internal/config"
+	"github.com/regrada-ai/regrada/internal/remote"
+	"github.com/regrada-ai/regrada/internal/store"
+)
+
+// pullBaselines refreshes the local baseline files from the configured
+// remote before a run. Sync is best-effort: a missing object just
+// means the remote has no baseline for that key yet, and transport
+// failures warn and leave the local copies in place.
+func pullBaselines(cfg *config.Config, st *store.Store) {
+	if cfg.Baseline.Remote == "" {
+		return
+	}
+	client, err := remote.New(cfg.Baseline.Remote)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "regrada: baseline remote: %v\n", err)
+		return
+	}
+	for _, key := range baselineSyncKeys(cfg) {
+		data, err := client.Get(key)
+		if errors.Is(err, remote.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "regrada: fetching %s from %s: %v\n", key, cfg.Baseline.Remote, err)
+			return
+		}
+		path := filepath.Join(st.Root(), filepath.FromSlash(key))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			fmt.Fprintf(os.Stderr, "regrada: baseline remote: %v\n", err)
+			return
+		}
+		if err := atomicfile.WriteFile(path, data, 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "regrada: baseline remote: %v\n", err)
+			return
+		}
+	}
+}
+
+// pushBaseline uploads one local baseline file to the configured
+// remote under its store-relative key, warning instead of failing so
+// a down remote never blocks a promotion.
+func pushBaseline(cfg *config.Config, st *store.Store, path string) {
+	if cfg.Baseline.Remote == "" {
+		return
+	}
+	key, err := filepath.Rel(st.Root(), path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "regrada: baseline remote: %v\n", err)
+		return
+	}
+	key = filepath.ToSlash(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "regrada: baseline remote: %v\n", err)
+		return
+	}
+	client, err := remote.New(cfg.Baseline.Remote)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "regrada: baseline remote: %v\n", err)
+		return
+	}
+	if err := client.Put(key, data); err != nil {
+		fmt.Fprintf(os.Stderr, "regrada: pushing %s to %s: %v\n", key, cfg.Baseline.Remote, err)
+		return
+	}
+	fmt.Printf("  pushed %s to %s\n", key, cfg.Baseline.Remote)
+}
+
+// baselineSyncKeys lists the store-relative baseline files a run might
+// compare against: the status baseline, the promoted results baseline
+// and the env/branch-keyed baselines selectBaselinePath considers.
+func baselineSyncKeys(cfg *config.Config) []string {
+	keys := []string{"baseline.json", "results-baseline.json"}
+	seen := map[string]bool{}
+	for _, k := range []string{baselineKey(cfg), "main", "master"} {
+		if k == "" || seen[k] {
+			continue
+		}
+		seen[k] = true
+		keys = append(keys, "baselines/"+k+".json")
+	}
+	return keys
+}
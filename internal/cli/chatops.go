@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/chatops"
+	"github.com/regrada-ai/regrada/internal/config"
+	"github.com/regrada-ai/regrada/internal/evals"
+	"github.com/regrada-ai/regrada/internal/gate"
+	"github.com/regrada-ai/regrada/internal/store"
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+// approvalTimeout bounds how long a run waits for a ChatOps decision.
+const approvalTimeout = 10 * time.Minute
+
+// chatOpsApproval posts the run's regressions to Slack and, when a
+// callback server is configured, blocks for an approve/reject decision.
+// An approval promotes the run to the results baseline; anything else
+// leaves the gate to fail as usual. It returns whether the regressions
+// were approved.
+func chatOpsApproval(cfg *config.Config, sum *runSummary) (bool, error) {
+	st := store.Open(cfg.StateDir())
+	res, err := evals.LoadResults(st.ResultsPath())
+	if err != nil {
+		return false, err
+	}
+	diff := chatops.Diff{
+		Project:     cfg.Project,
+		RunID:       res.RunID,
+		Score:       sum.Score,
+		Regressions: sum.Regressions,
+	}
+	base := cfg.Notify.CallbackURL
+	if base == "" {
+		base = "http://localhost" + cfg.Notify.CallbackAddr
+	}
+	if err := chatops.PostDiff(cfg.Notify.SlackWebhook, diff, base+"/approve", base+"/reject"); err != nil {
+		return false, err
+	}
+	if cfg.Notify.CallbackAddr == "" {
+		fmt.Println("Posted regression summary to Slack (no callback server configured).")
+		return false, nil
+	}
+	fmt.Printf("Posted regression summary to Slack; waiting up to %s for a decision...\n", approvalTimeout)
+	approved, err := chatops.AwaitDecision(cfg.Notify.CallbackAddr, approvalTimeout)
+	if err != nil {
+		return false, err
+	}
+	if !approved {
+		fmt.Println("Rejected via ChatOps.")
+		return false, nil
+	}
+	if err := res.Save(st.ResultsBaselinePath()); err != nil {
+		return false, err
+	}
+	fmt.Printf("Approved via ChatOps; promoted run %s to %s\n", res.RunID, st.ResultsBaselinePath())
+	return true, nil
+}
+
+// slackNotify posts a non-interactive summary to the configured Slack
+// webhook. Delivery failures warn instead of erroring: a flaky webhook
+// must not change the gate's verdict.
+func slackNotify(cfg *config.Config, s chatops.Summary) {
+	if cfg.Notify.SlackWebhook == "" {
+		return
+	}
+	if s.Branch == "" {
+		s.Branch = gitBranch()
+	}
+	if err := chatops.PostSummary(cfg.Notify.SlackWebhook, s); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		return
+	}
+	fmt.Println("Posted summary to Slack.")
+}
+
+// runFailureSummary shapes a failing run into the Slack notification,
+// including the estimated cost delta against the previous session when
+// two sessions exist to compare.
+func runFailureSummary(cfg *config.Config, sum *runSummary) chatops.Summary {
+	st := store.Open(cfg.StateDir())
+	s := chatops.Summary{Project: cfg.Project, Verdict: fmt.Sprintf("%d test(s) failed", sum.Failed)}
+	if len(sum.Regressions) > 0 {
+		s.Verdict = fmt.Sprintf("%d regression(s)", len(sum.Regressions))
+		s.Lines = sum.Regressions
+	}
+	if res, err := evals.LoadResults(st.ResultsPath()); err == nil {
+		s.RunID = res.RunID
+	}
+	applyPricing(cfg)
+	if sessions, err := trace.List(st.SessionsDir()); err == nil && len(sessions) >= 2 {
+		s.CostDeltaUSD = gate.EstimateCostUSD(sessions[0]) - gate.EstimateCostUSD(sessions[1])
+	}
+	return s
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/regrada-ai/regrada/internal/evals"
+)
+
+var checksCmd = &Command{
+	Name:    "checks",
+	Summary: "Discover the available check types",
+	Usage:   "list [--json]",
+}
+
+func init() {
+	checksCmd.Run = runChecks
+	register(checksCmd)
+}
+
+func runChecks(ctx *Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(checksCmd, "subcommand expected")
+	}
+	switch args[0] {
+	case "list":
+		return runChecksList(ctx, args[1:])
+	default:
+		return usageError(checksCmd, "unknown subcommand %q", args[0])
+	}
+}
+
+// runChecksList enumerates every registered check with its argument
+// grammar and description. The JSON form is stable output for editor
+// plugins and the test wizard to drive completion and validation.
+func runChecksList(ctx *Context, args []string) error {
+	fs := newFlagSet(checksCmd)
+	asJSON := fs.Bool("json", false, "emit machine-readable JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError(checksCmd, "unexpected argument %q", fs.Arg(0))
+	}
+
+	infos := evals.Checks()
+	if *asJSON {
+		data, err := json.MarshalIndent(infos, "", "  ")
+		if err != nil {
+			return err
+		}
+		data = append(data, '\n')
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	for _, info := range infos {
+		spec := info.Name
+		if info.Arg != "" {
+			spec += ":" + info.Arg
+		}
+		fmt.Printf("  %-45s %-12s %s\n", spec, info.Category, info.Description)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/regrada-ai/regrada/internal/gate"
+	"github.com/regrada-ai/regrada/internal/proxy"
+)
+
+var ciCmd = &Command{
+	Name:    "ci",
+	Summary: "Trace, evaluate and gate in one invocation",
+	Usage:   "[flags] [-- <command>]",
+}
+
+func init() {
+	ciCmd.Run = runCI
+	register(ciCmd)
+}
+
+// runCI collapses the usual CI recipe — trace the app's test command,
+// run the eval suite, gate on the outcome, emit a report — into one
+// command with one exit code.
+func runCI(ctx *Context, args []string) error {
+	fs := newFlagSet(ciCmd)
+	format := fs.String("format", "text", "report format: text or json")
+	envFlag := fs.String("env", "", "environment tag for the recorded session")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cmdArgs := fs.Args()
+	if len(cmdArgs) > 0 && cmdArgs[0] == "--" {
+		cmdArgs = cmdArgs[1:]
+	}
+
+	cfg, err := ctx.Config()
+	if err != nil {
+		return err
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	if len(cmdArgs) == 0 && cfg.CI.Command != "" {
+		cmdArgs = strings.Fields(cfg.CI.Command)
+	}
+	if *envFlag == "" {
+		*envFlag = cfg.Env
+	}
+
+	// Step 1: trace the app's test command, when one is configured.
+	if len(cmdArgs) > 0 {
+		p := proxy.New(proxy.Options{Env: *envFlag, Rewrites: cfg.Rewrite, Headers: cfg.ProviderHeaders(), Endpoints: cfg.ProviderEndpoints(), InjectMetadata: true})
+		base, err := p.Start()
+		if err != nil {
+			return fmt.Errorf("starting proxy: %w", err)
+		}
+		cmd := exec.Command(cmdArgs[0], cmdArgs[1:]...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		cmd.Env = append(os.Environ(), proxy.BaseURLEnv(base)...)
+		seed, sha := newRunSeed(), gitSHA()
+		cmd.Env = append(cmd.Env, runMetadataEnv(p.RunID(), seed, sha)...)
+		runErr := cmd.Run()
+		session := p.Close()
+		if session.Labels == nil {
+			session.Labels = map[string]string{}
+		}
+		session.Labels["seed"] = seed
+		if sha != "" {
+			session.Labels["git_sha"] = sha
+		}
+		applyPricing(cfg)
+		gate.StampCosts(session)
+		if _, err := session.Save(st.SessionsDir()); err != nil {
+			return err
+		}
+		fmt.Printf("Traced %d calls from %q\n", len(session.Calls), strings.Join(cmdArgs, " "))
+		exportOTel(cfg.OTel.Endpoint, session)
+		if runErr != nil {
+			return fmt.Errorf("traced command: %w", runErr)
+		}
+	}
+
+	// Step 2: run the eval suite against what was captured.
+	sum, err := runProject(cfg, runOptions{})
+	if err != nil {
+		return err
+	}
+	sum.print("")
+	emitGitHubSummary(sum)
+	publishCommitStatus(cfg, sum, len(sum.Regressions) > 0 || sum.failed())
+
+	// Step 3: emit the selected report format.
+	if *format == "json" {
+		data, err := os.ReadFile(st.ResultsPath())
+		if err != nil {
+			return err
+		}
+		var pretty json.RawMessage = data
+		os.Stdout.Write(pretty) //nolint:errcheck // report output
+	}
+
+	// Step 4: gate.
+	if len(sum.Regressions) > 0 {
+		return exitWithCode(fmt.Errorf("%d regressions against baseline", len(sum.Regressions)), 1)
+	}
+	if sum.failed() {
+		return exitWithCode(fmt.Errorf("%d tests failed", sum.Failed), 1)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/config"
+	"github.com/regrada-ai/regrada/internal/store"
+)
+
+var cleanCmd = &Command{
+	Name:    "clean",
+	Summary: "Remove old state according to the retention policy",
+	Usage:   "--results [--keep <n>] [--rollup-after <days>]",
+}
+
+func init() {
+	cleanCmd.Run = runClean
+	register(cleanCmd)
+}
+
+// runClean applies the retention policy on demand. The same policy
+// runs automatically after every `regrada run`; this command exists
+// for projects that only record in CI and prune elsewhere.
+func runClean(ctx *Context, args []string) error {
+	fs := newFlagSet(cleanCmd)
+	results := fs.Bool("results", false, "prune the run-history records")
+	keep := fs.Int("keep", 0, "keep at most this many recent runs (overrides retain.runs)")
+	rollupAfter := fs.Int("rollup-after", 0, "roll history older than this many days up to one run per day (overrides retain.rollup_after_days)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !*results {
+		return usageError(cleanCmd, "nothing selected; pass --results")
+	}
+	cfg, err := ctx.Config()
+	if err != nil {
+		return err
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+
+	ret := store.Retention{Runs: cfg.Retain.Runs, RollupAfterDays: cfg.Retain.RollupAfterDays}
+	if *keep > 0 {
+		ret.Runs = *keep
+	}
+	if *rollupAfter > 0 {
+		ret.RollupAfterDays = *rollupAfter
+	}
+	if !ret.Enabled() {
+		return fmt.Errorf("no retention policy: set retain in %s or pass --keep / --rollup-after", config.FileName)
+	}
+
+	before, err := filepath.Glob(filepath.Join(st.HistoryDir(), "*.json"))
+	if err != nil {
+		return err
+	}
+	removed, err := st.Prune(ret, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Removed %d of %d history records\n", removed, len(before))
+	return nil
+}
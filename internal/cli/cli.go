@@ -0,0 +1,171 @@
+// Package cli implements the regrada command-line interface. Each
+// subcommand lives in its own file and registers itself with the global
+// command table via register in an init function.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/regrada-ai/regrada/internal/config"
+	"github.com/regrada-ai/regrada/internal/store"
+)
+
+// Command describes a single regrada subcommand.
+type Command struct {
+	// Name is the subcommand name as typed on the command line.
+	Name string
+	// Summary is a one-line description shown in `regrada help`.
+	Summary string
+	// Usage is the argument synopsis, e.g. "[flags] <session-id>".
+	Usage string
+	// Run executes the command. args are the arguments after the
+	// subcommand name, with no flag parsing applied.
+	Run func(ctx *Context, args []string) error
+}
+
+// Context carries the loaded project configuration and state store into
+// command implementations. The config is loaded lazily so commands that
+// do not need a project (e.g. version, init) work outside one.
+type Context struct {
+	cfg   *config.Config
+	store *store.Store
+}
+
+// Config loads and caches the project configuration.
+func (c *Context) Config() (*config.Config, error) {
+	if c.cfg == nil {
+		cfg, err := config.Load()
+		if err != nil {
+			return nil, err
+		}
+		c.cfg = cfg
+	}
+	return c.cfg, nil
+}
+
+// Store returns the state store rooted at the project's .regrada
+// directory.
+func (c *Context) Store() (*store.Store, error) {
+	if c.store == nil {
+		cfg, err := c.Config()
+		if err != nil {
+			return nil, err
+		}
+		c.store = store.Open(cfg.StateDir())
+	}
+	return c.store, nil
+}
+
+var commands = map[string]*Command{}
+
+func register(cmd *Command) {
+	if _, dup := commands[cmd.Name]; dup {
+		panic("cli: duplicate command " + cmd.Name)
+	}
+	commands[cmd.Name] = cmd
+}
+
+// exitError wraps an error with a specific process exit code.
+type exitError struct {
+	err  error
+	code int
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// exitWithCode returns err annotated so that the process exits with the
+// given code instead of the default 1.
+func exitWithCode(err error, code int) error {
+	return &exitError{err: err, code: code}
+}
+
+// ExitCode returns the process exit code for err.
+func ExitCode(err error) int {
+	var ee *exitError
+	if ok := asExitError(err, &ee); ok {
+		return ee.code
+	}
+	return 1
+}
+
+func asExitError(err error, target **exitError) bool {
+	for err != nil {
+		if ee, ok := err.(*exitError); ok {
+			*target = ee
+			return true
+		}
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return false
+		}
+		err = u.Unwrap()
+	}
+	return false
+}
+
+// Main dispatches to the named subcommand.
+func Main(args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		if len(args) > 1 {
+			return printCommandHelp(args[1])
+		}
+		printUsage(os.Stdout)
+		return nil
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		printUsage(os.Stderr)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	return cmd.Run(&Context{}, args[1:])
+}
+
+func printCommandHelp(name string) error {
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q", name)
+	}
+	fmt.Printf("usage: regrada %s %s\n\n%s\n", cmd.Name, cmd.Usage, cmd.Summary)
+	return nil
+}
+
+func printUsage(w *os.File) {
+	fmt.Fprintln(w, "usage: regrada <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		// Internal callbacks (completion helpers) stay out of help.
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-12s %s\n", name, commands[name].Summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "regrada help <command>" for details.`)
+}
+
+// newFlagSet returns a FlagSet wired with the command's usage text.
+func newFlagSet(cmd *Command) *flag.FlagSet {
+	fs := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: regrada %s %s\n", cmd.Name, cmd.Usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// usageError reports a bad invocation in a consistent form.
+func usageError(cmd *Command, format string, a ...any) error {
+	msg := fmt.Sprintf(format, a...)
+	return fmt.Errorf("%s (usage: regrada %s %s)", msg, cmd.Name, strings.TrimSpace(cmd.Usage))
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/regrada-ai/regrada/internal/cluster"
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+var clusterCmd = &Command{
+	Name:    "cluster",
+	Summary: "Group recorded responses into behavioral clusters",
+	Usage:   "[session-id...]",
+}
+
+func init() {
+	clusterCmd.Run = runCluster
+	register(clusterCmd)
+}
+
+// runCluster clusters response text across sessions (all recorded
+// sessions by default) and flags clusters populated only by the newest
+// session: those are behaviors no earlier run produced.
+func runCluster(ctx *Context, args []string) error {
+	fs := newFlagSet(clusterCmd)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+
+	var sessions []*trace.Session
+	if fs.NArg() > 0 {
+		for _, id := range fs.Args() {
+			s, err := trace.Find(st.SessionsDir(), id)
+			if err != nil {
+				return err
+			}
+			sessions = append(sessions, s)
+		}
+	} else {
+		sessions, err = trace.List(st.SessionsDir())
+		if err != nil {
+			return err
+		}
+	}
+	if len(sessions) == 0 {
+		return fmt.Errorf("no sessions to cluster in %s", st.SessionsDir())
+	}
+	// List and Find both return newest first / as given; the first
+	// session is treated as the run under inspection.
+	latest := sessions[0]
+
+	clusters := cluster.Sessions(sessions)
+	if len(clusters) == 0 {
+		fmt.Println("No response text found in the given sessions.")
+		return nil
+	}
+
+	newClusters := 0
+	fmt.Printf("%d clusters across %d sessions\n\n", len(clusters), len(sessions))
+	for i, cl := range clusters {
+		marker := " "
+		if len(sessions) > 1 && cl.OnlyFrom(latest.ID) {
+			marker = "!"
+			newClusters++
+		}
+		rep := cl.Representative()
+		fmt.Printf("%s cluster %d: %d responses\n", marker, i+1, len(cl.Members))
+		fmt.Printf("    e.g. %s (call %d): %s\n", rep.SessionID, rep.CallIndex, snippet(rep.Text, 100))
+	}
+	if newClusters > 0 {
+		fmt.Printf("\n%d cluster(s) marked ! appear only in session %s — behavior earlier runs never produced.\n",
+			newClusters, latest.ID)
+	}
+	return nil
+}
+
+// snippet returns text flattened to one line and capped at n runes.
+func snippet(text string, n int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n]) + "..."
+}
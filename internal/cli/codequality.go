@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/regrada-ai/regrada/internal/atomicfile"
+	"github.com/regrada-ai/regrada/internal/evals"
+)
+
+// GitLab Code Quality report format: one issue per failed check, shown
+// inline on the merge request diff view.
+type codeQualityIssue struct {
+	Description string `json:"description"`
+	CheckName   string `json:"check_name"`
+	// Fingerprint deduplicates the issue across pipeline runs.
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    codeQualityLocation `json:"location"`
+}
+
+type codeQualityLocation struct {
+	Path  string `json:"path"`
+	Lines struct {
+		Begin int `json:"begin"`
+	} `json:"lines"`
+}
+
+// writeCodeQuality renders failed checks as a GitLab Code Quality
+// report at path, attributing every issue to the suite file since the
+// failing behavior lives in the model, not on one source line.
+func writeCodeQuality(res *evals.Results, suitePath, path string) error {
+	issues := []codeQualityIssue{}
+	for _, tr := range res.Tests {
+		for _, c := range tr.Checks {
+			if c.Passed {
+				continue
+			}
+			severity := "major"
+			if tr.Status == evals.StatusError || tr.Status == evals.StatusInfraError {
+				severity = "critical"
+			}
+			sum := md5.Sum([]byte(res.Suite + "/" + tr.Name + "/" + c.Name))
+			issue := codeQualityIssue{
+				Description: fmt.Sprintf("%s: %s failed — %s", tr.Name, c.Name, c.Message),
+				CheckName:   c.Name,
+				Fingerprint: hex.EncodeToString(sum[:]),
+				Severity:    severity,
+			}
+			issue.Location.Path = suitePath
+			issue.Location.Lines.Begin = 1
+			issues = append(issues, issue)
+		}
+	}
+	data, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(path, append(data, '\n'), 0o644)
+}
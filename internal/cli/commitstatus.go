@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/config"
+	"github.com/regrada-ai/regrada/internal/evals"
+	"github.com/regrada-ai/regrada/internal/store"
+)
+
+// publishCommitStatus posts the gate verdict for the current commit as
+// a GitHub commit status named "regrada gate", so branch protection
+// rules can require it directly. It is a no-op unless ci.commit_status
+// is enabled; like the other notification paths, failures only warn
+// because the run itself already has an exit code.
+func publishCommitStatus(cfg *config.Config, sum *runSummary, failed bool) {
+	if !cfg.CI.CommitStatus {
+		return
+	}
+	repo := os.Getenv("GITHUB_REPOSITORY")
+	sha := gitSHA()
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		token = os.Getenv("GH_TOKEN")
+	}
+	if repo == "" || sha == "" || token == "" {
+		fmt.Fprintln(os.Stderr, "regrada: commit status needs GITHUB_REPOSITORY, a commit SHA and GITHUB_TOKEN")
+		return
+	}
+
+	state := "success"
+	desc := fmt.Sprintf("%d passed (score %.2f)", sum.Passed, sum.Score)
+	if failed {
+		state = "failure"
+		desc = fmt.Sprintf("%d failed, %d regressions (score %.2f)", sum.Failed, len(sum.Regressions), sum.Score)
+	}
+	payload := map[string]string{
+		"state":       state,
+		"context":     "regrada gate",
+		"description": desc,
+	}
+	if url := reportURL(cfg); url != "" {
+		payload["target_url"] = url
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+
+	api := os.Getenv("GITHUB_API_URL")
+	if api == "" {
+		api = "https://api.github.com"
+	}
+	url := fmt.Sprintf("%s/repos/%s/statuses/%s", strings.TrimSuffix(api, "/"), repo, sha)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "regrada: commit status: %v\n", err)
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "regrada: commit status: %v\n", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		fmt.Fprintf(os.Stderr, "regrada: commit status: %s returned %s\n", url, resp.Status)
+		return
+	}
+	fmt.Printf("Published %s commit status for %.12s\n", state, sha)
+}
+
+// reportURL derives the stable URL of the latest run's uploaded HTML
+// report, mirroring the per-run key layout of `artifacts push`, when
+// the artifacts destination is an HTTP location.
+func reportURL(cfg *config.Config) string {
+	dest := strings.TrimSuffix(cfg.Artifacts.Destination, "/")
+	if !strings.HasPrefix(dest, "http://") && !strings.HasPrefix(dest, "https://") {
+		return ""
+	}
+	st := store.Open(cfg.StateDir())
+	res, err := evals.LoadResults(st.ResultsPath())
+	if err != nil {
+		return ""
+	}
+	return dest + "/" + res.RunID + "/" + res.RunID + ".html"
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/regrada-ai/regrada/internal/evals"
+)
+
+var completionCmd = &Command{
+	Name:    "completion",
+	Summary: "Print a shell completion script",
+	Usage:   "bash|zsh|fish",
+}
+
+// completeCmd is the hidden callback the shell scripts use for dynamic
+// values. It prints one candidate per line and never fails, since a
+// completion error would garble the user's prompt.
+var completeCmd = &Command{
+	Name:    "__complete",
+	Summary: "Internal: list completion candidates",
+	Usage:   "commands|tests|sessions",
+}
+
+func init() {
+	completionCmd.Run = runCompletion
+	completeCmd.Run = runComplete
+	register(completionCmd)
+	register(completeCmd)
+}
+
+func runCompletion(ctx *Context, args []string) error {
+	if len(args) != 1 {
+		return usageError(completionCmd, "shell name expected")
+	}
+	switch args[0] {
+	case "bash":
+		fmt.Print(bashCompletion)
+	case "zsh":
+		fmt.Print(zshCompletion)
+	case "fish":
+		fmt.Print(fishCompletion)
+	default:
+		return usageError(completionCmd, "unsupported shell %q", args[0])
+	}
+	return nil
+}
+
+func runComplete(ctx *Context, args []string) error {
+	if len(args) != 1 {
+		return nil
+	}
+	var candidates []string
+	switch args[0] {
+	case "commands":
+		for name := range commands {
+			if !strings.HasPrefix(name, "__") {
+				candidates = append(candidates, name)
+			}
+		}
+	case "tests":
+		cfg, err := ctx.Config()
+		if err != nil {
+			return nil
+		}
+		suite, err := evals.LoadSuite(cfg.SuitePath())
+		if err != nil {
+			return nil
+		}
+		for _, t := range suite.Tests {
+			candidates = append(candidates, t.Name)
+		}
+	case "sessions":
+		st, err := ctx.Store()
+		if err != nil {
+			return nil
+		}
+		matches, err := filepath.Glob(filepath.Join(st.SessionsDir(), "*.json"))
+		if err != nil {
+			return nil
+		}
+		for _, m := range matches {
+			candidates = append(candidates, strings.TrimSuffix(filepath.Base(m), ".json"))
+		}
+	}
+	sort.Strings(candidates)
+	for _, c := range candidates {
+		fmt.Println(c)
+	}
+	return nil
+}
+
+const bashCompletion = `# bash completion for regrada
+_regrada() {
+    local cur prev
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    if [[ ${COMP_CWORD} -eq 1 ]]; then
+        COMPREPLY=( $(compgen -W "$(regrada __complete commands)" -- "${cur}") )
+        return
+    fi
+    case "${COMP_WORDS[1]}" in
+        replay)
+            COMPREPLY=( $(compgen -W "$(regrada __complete sessions)" -- "${cur}") )
+            ;;
+        baseline|run)
+            if [[ "${prev}" == "--tests" || "${prev}" == "-tests" ]]; then
+                COMPREPLY=( $(compgen -W "$(regrada __complete tests)" -- "${cur}") )
+            fi
+            ;;
+    esac
+}
+complete -F _regrada regrada
+`
+
+const zshCompletion = `# zsh completion for regrada
+#compdef regrada
+_regrada() {
+    local -a candidates
+    if (( CURRENT == 2 )); then
+        candidates=(${(f)"$(regrada __complete commands)"})
+    else
+        case "${words[2]}" in
+            replay) candidates=(${(f)"$(regrada __complete sessions)"}) ;;
+            baseline|run) candidates=(${(f)"$(regrada __complete tests)"}) ;;
+        esac
+    fi
+    compadd -a candidates
+}
+compdef _regrada regrada
+`
+
+const fishCompletion = `# fish completion for regrada
+complete -c regrada -n "__fish_use_subcommand" -a "(regrada __complete commands)"
+complete -c regrada -n "__fish_seen_subcommand_from replay" -a "(regrada __complete sessions)"
+complete -c regrada -n "__fish_seen_subcommand_from baseline run" -a "(regrada __complete tests)"
+`
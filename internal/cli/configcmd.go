@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/regrada-ai/regrada/internal/config"
+)
+
+var configCmd = &Command{
+	Name:    "config",
+	Summary: "Read and modify .regrada.yaml from scripts",
+	Usage:   "get <key> | set <key> <value>",
+}
+
+func init() {
+	configCmd.Run = runConfig
+	register(configCmd)
+}
+
+func runConfig(ctx *Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(configCmd, "subcommand expected")
+	}
+	cfg, err := ctx.Config()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(cfg.Root, config.FileName)
+	switch args[0] {
+	case "get":
+		if len(args) != 2 {
+			return usageError(configCmd, "get takes exactly one key")
+		}
+		value, err := config.Get(path, args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Println(value)
+		return nil
+	case "set":
+		if len(args) != 3 {
+			return usageError(configCmd, "set takes a key and a value")
+		}
+		return config.Set(path, args[1], args[2])
+	default:
+		return usageError(configCmd, "unknown subcommand %q", args[0])
+	}
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/regrada-ai/regrada/internal/browser"
+	"github.com/regrada-ai/regrada/internal/dashboard"
+)
+
+var dashboardCmd = &Command{
+	Name:    "dashboard",
+	Summary: "Serve a local web UI for browsing traces and results",
+	Usage:   "[--addr 127.0.0.1:8091] [--no-open]",
+}
+
+func init() {
+	dashboardCmd.Run = runDashboard
+	register(dashboardCmd)
+}
+
+// runDashboard serves the embedded web UI over the project's state:
+// sessions with per-call drill-down, results diffed against the
+// baseline, and cost/latency history — the things that are painful to
+// dig out of a 500-call JSON file by hand.
+func runDashboard(ctx *Context, args []string) error {
+	fs := newFlagSet(dashboardCmd)
+	addr := fs.String("addr", "127.0.0.1:8091", "listen address")
+	noOpen := fs.Bool("no-open", false, "do not open the browser")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError(dashboardCmd, "unexpected argument %q", fs.Arg(0))
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+
+	// Listen before opening the browser so the first page load never
+	// races the server coming up.
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	url := "http://" + ln.Addr().String()
+	fmt.Printf("Dashboard for %s on %s\n", st.Root(), url)
+	if !*noOpen {
+		if err := browser.Open(url); err != nil {
+			fmt.Fprintf(os.Stderr, "regrada: opening browser: %v\n", err)
+		}
+	}
+	return http.Serve(ln, dashboard.New(st).Handler())
+}
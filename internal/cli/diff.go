@@ -0,0 +1,339 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/regrada-ai/regrada/internal/evals"
+	"github.com/regrada-ai/regrada/internal/judge"
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+var diffCmd = &Command{
+	Name:    "diff",
+	Summary: "Compare sessions, results or environments",
+	Usage:   "[<a> <b>] | --env <a> --env <b>",
+}
+
+func init() {
+	diffCmd.Run = runDiff
+	register(diffCmd)
+}
+
+// stringsFlag collects a repeatable string flag.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string     { return fmt.Sprint([]string(*f)) }
+func (f *stringsFlag) Set(v string) error { *f = append(*f, v); return nil }
+
+func runDiff(ctx *Context, args []string) error {
+	fs := newFlagSet(diffCmd)
+	var envs stringsFlag
+	fs.Var(&envs, "env", "environment to compare (repeat twice)")
+	inline := fs.Bool("inline", false, "render colorized prompt/response diffs in the terminal")
+	summarize := fs.Bool("summarize", false, "describe behavioral changes in prose via the judge model (sends redacted responses)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if len(envs) == 2 {
+		if fs.NArg() > 0 {
+			return usageError(diffCmd, "positional arguments cannot be combined with --env")
+		}
+		return diffEnvs(ctx, envs[0], envs[1])
+	}
+	if len(envs) != 0 {
+		return usageError(diffCmd, "expected two --env flags")
+	}
+	switch fs.NArg() {
+	case 0:
+		return diffLatestVsBaseline(ctx, *inline, *summarize)
+	case 2:
+		return diffFiles(ctx, fs.Arg(0), fs.Arg(1), *inline, *summarize)
+	default:
+		return usageError(diffCmd, "expected two sessions/results files, two --env flags, or no arguments")
+	}
+}
+
+// diffLatestVsBaseline compares the latest run's results against the
+// promoted results baseline.
+func diffLatestVsBaseline(ctx *Context, inline, summarize bool) error {
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	if !fileExists(st.ResultsBaselinePath()) {
+		return fmt.Errorf("no results baseline at %s; promote one with `regrada baseline promote` or name two files", st.ResultsBaselinePath())
+	}
+	if !fileExists(st.ResultsPath()) {
+		return fmt.Errorf("no results at %s; run `regrada run` first", st.ResultsPath())
+	}
+	return diffFiles(ctx, st.ResultsBaselinePath(), st.ResultsPath(), inline, summarize)
+}
+
+// diffFiles dispatches on the shape of the two inputs: eval results
+// files are compared test by test, trace sessions call by call. A name
+// that is not a file is tried as a recorded session id.
+func diffFiles(ctx *Context, aName, bName string, inline, summarize bool) error {
+	aRes, aSess, err := loadDiffable(ctx, aName)
+	if err != nil {
+		return err
+	}
+	bRes, bSess, err := loadDiffable(ctx, bName)
+	if err != nil {
+		return err
+	}
+	switch {
+	case aRes != nil && bRes != nil:
+		if inline {
+			return fmt.Errorf("--inline diffs trace sessions; results files have no call bodies to render")
+		}
+		if err := diffResults(aRes, bRes); err != nil {
+			return err
+		}
+		if summarize {
+			return summarizeResultsDiff(ctx, aRes, bRes)
+		}
+		return nil
+	case aSess != nil && bSess != nil:
+		if inline {
+			return diffSessionsInline(aSess, bSess)
+		}
+		return diffSessions(aSess, bSess)
+	default:
+		return fmt.Errorf("cannot diff a results file against a trace session")
+	}
+}
+
+// summarizeResultsDiff asks the judge model for a prose description of
+// the tests whose status changed between the two runs.
+func summarizeResultsDiff(ctx *Context, a, b *evals.Results) error {
+	cfg, err := ctx.Config()
+	if err != nil {
+		return err
+	}
+	var changes []judge.Change
+	for _, tb := range b.Tests {
+		if ta := a.Test(tb.Name); ta != nil && ta.Status != tb.Status {
+			changes = append(changes, judge.Change{Test: tb.Name, Before: ta.Response, After: tb.Response})
+		}
+	}
+	if len(changes) == 0 {
+		fmt.Println("No status changes to summarize.")
+		return nil
+	}
+	summary, err := judge.Summarize(cfg.Judge.Model, changes)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nChange summary:\n%s\n", summary)
+	return nil
+}
+
+// loadDiffable loads name as either an eval results file or a trace
+// session (by path or recorded session id).
+func loadDiffable(ctx *Context, name string) (*evals.Results, *trace.Session, error) {
+	if !fileExists(name) {
+		st, err := ctx.Store()
+		if err != nil {
+			return nil, nil, err
+		}
+		s, err := trace.Find(st.SessionsDir(), name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%q is neither a file nor a recorded session id", name)
+		}
+		return nil, s, nil
+	}
+	if res, err := evals.LoadResults(name); err == nil {
+		return res, nil, nil
+	}
+	s, err := trace.Load(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, s, nil
+}
+
+// diffSessions prints call-by-call differences between two sessions.
+// Calls are paired by endpoint in order of occurrence; unpaired calls
+// are reported as added or removed.
+func diffSessions(a, b *trace.Session) error {
+	fmt.Printf("%s (%d calls) vs %s (%d calls)\n\n", a.ID, len(a.Calls), b.ID, len(b.Calls))
+	changes := 0
+
+	byEndpoint := func(s *trace.Session) (map[string][]*trace.Call, []string) {
+		m := map[string][]*trace.Call{}
+		var order []string
+		for i := range s.Calls {
+			c := &s.Calls[i]
+			key := c.Method + " " + stripQuery(c.URL)
+			if _, seen := m[key]; !seen {
+				order = append(order, key)
+			}
+			m[key] = append(m[key], c)
+		}
+		return m, order
+	}
+	aCalls, order := byEndpoint(a)
+	bCalls, bOrder := byEndpoint(b)
+	for _, key := range bOrder {
+		if _, ok := aCalls[key]; !ok {
+			order = append(order, key)
+		}
+	}
+
+	for _, key := range order {
+		as, bs := aCalls[key], bCalls[key]
+		n := len(as)
+		if len(bs) > n {
+			n = len(bs)
+		}
+		for i := 0; i < n; i++ {
+			switch {
+			case i >= len(bs):
+				fmt.Printf("  - call %d %s (removed)\n", as[i].Index, key)
+				changes++
+			case i >= len(as):
+				fmt.Printf("  + call %d %s (added)\n", bs[i].Index, key)
+				changes++
+			default:
+				changes += diffCallPair(key, as[i], bs[i])
+			}
+		}
+	}
+	if changes == 0 {
+		fmt.Println("  no differences")
+	}
+	return nil
+}
+
+// diffCallPair prints what changed between two paired calls and
+// returns the number of differences found.
+func diffCallPair(key string, a, b *trace.Call) int {
+	changes := 0
+	if a.Model != b.Model {
+		fmt.Printf("  ~ %s: model %s -> %s\n", key, a.Model, b.Model)
+		changes++
+	}
+	if !bytes.Equal(a.Request, b.Request) {
+		fmt.Printf("  ~ %s: request body changed (%d -> %d bytes)\n", key, len(a.Request), len(b.Request))
+		changes++
+	}
+	if at, bt := strings.Join(a.ToolCalls, ","), strings.Join(b.ToolCalls, ","); at != bt {
+		fmt.Printf("  ~ %s: tool calls [%s] -> [%s]\n", key, at, bt)
+		changes++
+	}
+	if da := (b.PromptTokens + b.CompletionTokens) - (a.PromptTokens + a.CompletionTokens); da != 0 {
+		fmt.Printf("  ~ %s: tokens %d -> %d (%+d)\n", key,
+			a.PromptTokens+a.CompletionTokens, b.PromptTokens+b.CompletionTokens, da)
+		changes++
+	}
+	if dms := b.DurationMS - a.DurationMS; dms > 100 || dms < -100 {
+		fmt.Printf("  ~ %s: latency %.0fms -> %.0fms (%+.0fms)\n", key, a.DurationMS, b.DurationMS, dms)
+		changes++
+	}
+	return changes
+}
+
+// stripQuery removes the query string so endpoint pairing ignores
+// per-call parameters like api-version.
+func stripQuery(url string) string {
+	if i := strings.IndexByte(url, '?'); i >= 0 {
+		return url[:i]
+	}
+	return url
+}
+
+// diffEnvs compares aggregated behavior between two environments so
+// config drift (wrong model, missing tool, latency cliff) shows up
+// without digging through individual sessions.
+func diffEnvs(ctx *Context, envA, envB string) error {
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	sessions, err := trace.List(st.SessionsDir())
+	if err != nil {
+		return err
+	}
+	profA := trace.BuildProfile(trace.FilterEnv(sessions, envA))
+	profB := trace.BuildProfile(trace.FilterEnv(sessions, envB))
+	if profA.Sessions == 0 {
+		return fmt.Errorf("no sessions tagged env=%q", envA)
+	}
+	if profB.Sessions == 0 {
+		return fmt.Errorf("no sessions tagged env=%q", envB)
+	}
+
+	fmt.Printf("%s: %d sessions, %d calls    %s: %d sessions, %d calls\n\n",
+		envA, profA.Sessions, profA.Calls, envB, profB.Sessions, profB.Calls)
+
+	fmt.Println("Models:")
+	printCountDiff(envA, envB, profA.Models, profB.Models)
+	fmt.Println("Tool calls:")
+	printCountDiff(envA, envB, profA.Tools, profB.Tools)
+	if len(profA.Services) > 0 || len(profB.Services) > 0 {
+		fmt.Println("Calls per service:")
+		printCountDiff(envA, envB, profA.Services, profB.Services)
+	}
+	if len(profA.Endpoints) > 0 || len(profB.Endpoints) > 0 {
+		fmt.Println("Calls per endpoint:")
+		printEndpointDiff(envA, envB, profA.Endpoints, profB.Endpoints)
+	}
+	if len(profA.Languages) > 0 || len(profB.Languages) > 0 {
+		fmt.Println("Response languages:")
+		printCountDiff(envA, envB, profA.Languages, profB.Languages)
+	}
+	if profA.EmbeddingCalls > 0 || profB.EmbeddingCalls > 0 {
+		fmt.Printf("Embedding calls: %s=%d (%d tokens), %s=%d (%d tokens)\n",
+			envA, profA.EmbeddingCalls, profA.EmbeddingTokens,
+			envB, profB.EmbeddingCalls, profB.EmbeddingTokens)
+	}
+	fmt.Printf("Mean latency: %s %.0fms, %s %.0fms (%+.0fms)\n",
+		envA, profA.MeanLatencyMS, envB, profB.MeanLatencyMS,
+		profB.MeanLatencyMS-profA.MeanLatencyMS)
+	fmt.Printf("P95 latency:  %s %.0fms, %s %.0fms (%+.0fms)\n",
+		envA, profA.P95LatencyMS, envB, profB.P95LatencyMS,
+		profB.P95LatencyMS-profA.P95LatencyMS)
+	return nil
+}
+
+// printEndpointDiff renders per-endpoint call counts and mean latency
+// side by side, marking endpoints present in only one environment.
+func printEndpointDiff(envA, envB string, a, b map[string]*trace.EndpointStats) {
+	keys := trace.SortedEndpoints(a, b)
+	for _, k := range keys {
+		sa, sb := a[k], b[k]
+		marker := " "
+		switch {
+		case sa == nil:
+			marker = "+"
+			sa = &trace.EndpointStats{}
+		case sb == nil:
+			marker = "-"
+			sb = &trace.EndpointStats{}
+		}
+		fmt.Printf("  %s %-40s %s=%d (%.0fms) %s=%d (%.0fms)\n",
+			marker, k, envA, sa.Calls, sa.MeanLatencyMS, envB, sb.Calls, sb.MeanLatencyMS)
+	}
+	fmt.Println()
+}
+
+func printCountDiff(envA, envB string, a, b map[string]int) {
+	keys := trace.SortedKeys(a, b)
+	if len(keys) == 0 {
+		fmt.Println("  (none)")
+		return
+	}
+	for _, k := range keys {
+		marker := " "
+		switch {
+		case a[k] == 0:
+			marker = "+" // only in envB
+		case b[k] == 0:
+			marker = "-" // only in envA
+		}
+		fmt.Printf("  %s %-40s %s=%d %s=%d\n", marker, k, envA, a[k], envB, b[k])
+	}
+	fmt.Println()
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/regrada-ai/regrada/internal/evals"
+)
+
+var diffResultsCmd = &Command{
+	Name:    "diff-results",
+	Summary: "Compare two eval result files test by test",
+	Usage:   "<a/results.json> <b/results.json>",
+}
+
+func init() {
+	diffResultsCmd.Run = runDiffResults
+	register(diffResultsCmd)
+}
+
+// runDiffResults compares two runs directly, independent of the
+// baseline mechanism, for ad-hoc comparisons between branches.
+func runDiffResults(ctx *Context, args []string) error {
+	fs := newFlagSet(diffResultsCmd)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return usageError(diffResultsCmd, "exactly two results files expected")
+	}
+	a, err := evals.LoadResults(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	b, err := evals.LoadResults(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	return diffResults(a, b)
+}
+
+// diffResults prints the test-by-test comparison; also reached through
+// `regrada diff` when both inputs are results files.
+func diffResults(a, b *evals.Results) error {
+	fmt.Printf("%s (%s) vs %s (%s)\n\n", a.RunID, a.Suite, b.RunID, b.Suite)
+	changes := 0
+	seen := map[string]bool{}
+	for _, ta := range a.Tests {
+		seen[ta.Name] = true
+		tb := b.Test(ta.Name)
+		if tb == nil {
+			fmt.Printf("  - %s (only in first run, was %s)\n", ta.Name, ta.Status)
+			changes++
+			continue
+		}
+		if ta.Status != tb.Status {
+			fmt.Printf("  ~ %s: %s -> %s\n", ta.Name, ta.Status, tb.Status)
+			changes++
+		}
+		if delta := tb.Score - ta.Score; delta > 0.01 || delta < -0.01 {
+			fmt.Printf("  ~ %s: score %.2f -> %.2f (%+.2f)\n", ta.Name, ta.Score, tb.Score, delta)
+			changes++
+		}
+		if delta := tb.Duration - ta.Duration; delta > 0.5 || delta < -0.5 {
+			fmt.Printf("  ~ %s: duration %.2fs -> %.2fs (%+.2fs)\n", ta.Name, ta.Duration, tb.Duration, delta)
+			changes++
+		}
+	}
+	for _, tb := range b.Tests {
+		if !seen[tb.Name] {
+			fmt.Printf("  + %s (only in second run, %s)\n", tb.Name, tb.Status)
+			changes++
+		}
+	}
+	if changes == 0 {
+		fmt.Println("  no differences")
+	}
+	return nil
+}
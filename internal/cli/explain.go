@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/regrada-ai/regrada/internal/baseline"
+	"github.com/regrada-ai/regrada/internal/evals"
+)
+
+var explainCmd = &Command{
+	Name:    "explain",
+	Summary: "Explain why a test failed in the last run",
+	Usage:   "[--json] <test>",
+}
+
+func init() {
+	explainCmd.Run = runExplain
+	register(explainCmd)
+}
+
+// explanation is the machine-readable form of an explain report.
+type explanation struct {
+	Test           string              `json:"test"`
+	RunID          string              `json:"run_id"`
+	Status         string              `json:"status"`
+	BaselineStatus string              `json:"baseline_status,omitempty"`
+	Prompt         string              `json:"prompt"`
+	Response       string              `json:"response"`
+	FailedChecks   []evals.CheckResult `json:"failed_checks"`
+}
+
+func runExplain(ctx *Context, args []string) error {
+	fs := newFlagSet(explainCmd)
+	asJSON := fs.Bool("json", false, "print machine-readable JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError(explainCmd, "exactly one test name expected")
+	}
+	name := fs.Arg(0)
+
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	res, err := evals.LoadResults(st.ResultsPath())
+	if err != nil {
+		return fmt.Errorf("no results to explain; run the suite first: %w", err)
+	}
+	tr := res.Test(name)
+	if tr == nil {
+		return fmt.Errorf("test %q was not part of run %s", name, res.RunID)
+	}
+
+	exp := explanation{
+		Test:     tr.Name,
+		RunID:    res.RunID,
+		Status:   tr.Status,
+		Prompt:   tr.Prompt,
+		Response: tr.Response,
+	}
+	for _, c := range tr.Checks {
+		if !c.Passed {
+			exp.FailedChecks = append(exp.FailedChecks, c)
+		}
+	}
+	if b, err := baseline.Load(st.BaselinePath()); err == nil {
+		if bt, ok := b.Tests[name]; ok {
+			exp.BaselineStatus = bt.Status
+		}
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(exp)
+	}
+
+	fmt.Printf("%s (%s) in run %s\n", exp.Test, exp.Status, exp.RunID)
+	if exp.BaselineStatus != "" {
+		fmt.Printf("baseline: %s", exp.BaselineStatus)
+		if exp.BaselineStatus == evals.StatusPass && exp.Status == evals.StatusFail {
+			fmt.Print("  <- regression")
+		}
+		fmt.Println()
+	}
+	fmt.Printf("\nPrompt:\n%s\n", indentBlock(exp.Prompt))
+	if exp.Response != "" {
+		fmt.Printf("\nResponse:\n%s\n", indentBlock(exp.Response))
+	} else {
+		fmt.Println("\nResponse: (none captured)")
+	}
+	if len(exp.FailedChecks) == 0 {
+		fmt.Println("\nNo failing checks.")
+		return nil
+	}
+	fmt.Println("\nFailing checks:")
+	for _, c := range exp.FailedChecks {
+		fmt.Printf("  ✗ %s", c.Name)
+		if c.Message != "" {
+			fmt.Printf(": %s", c.Message)
+		}
+		fmt.Println()
+		for _, key := range sortedEvidenceKeys(c.Evidence) {
+			fmt.Printf("      %s: %v\n", key, c.Evidence[key])
+		}
+	}
+	return nil
+}
+
+func sortedEvidenceKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func indentBlock(s string) string {
+	if s == "" {
+		return "  (empty)"
+	}
+	out := ""
+	for _, line := range splitLines(s) {
+		out += "  " + line + "\n"
+	}
+	return out[:len(out)-1]
+}
+
+func splitLines(s string) []string {
+	var lines []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			lines = append(lines, s[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(s) {
+		lines = append(lines, s[start:])
+	}
+	return lines
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/regrada-ai/regrada/internal/chatops"
+	"github.com/regrada-ai/regrada/internal/config"
+	"github.com/regrada-ai/regrada/internal/gate"
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+var gateCmd = &Command{
+	Name:    "gate",
+	Summary: "Check recorded behavior against the gate policy",
+	Usage:   "check",
+}
+
+func init() {
+	gateCmd.Run = runGate
+	register(gateCmd)
+}
+
+func runGate(ctx *Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(gateCmd, "subcommand expected")
+	}
+	switch args[0] {
+	case "check":
+		return runGateCheck(ctx, args[1:])
+	default:
+		return usageError(gateCmd, "unknown subcommand %q", args[0])
+	}
+}
+
+// runGateCheck evaluates the latest session against the policy in
+// .regrada/gates.yaml and exits non-zero on any violation, so CI can
+// block merges on cost, latency or model-usage regressions.
+func runGateCheck(ctx *Context, args []string) error {
+	fs := newFlagSet(gateCmd)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError(gateCmd, "unexpected argument %q", fs.Arg(0))
+	}
+	cfg, err := ctx.Config()
+	if err != nil {
+		return err
+	}
+	applyPricing(cfg)
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	if !fileExists(st.GatesPath()) {
+		return fmt.Errorf("no gate policy at %s; create one with rules like max_p95_latency_ms or forbidden_models", st.GatesPath())
+	}
+	policy, err := gate.LoadPolicy(st.GatesPath())
+	if err != nil {
+		return err
+	}
+
+	sessions, err := trace.List(st.SessionsDir())
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		return fmt.Errorf("no recorded sessions in %s; trace a run first", st.SessionsDir())
+	}
+	current, earlier := sessions[0], sessions[1:]
+
+	violations := policy.Evaluate(current, earlier)
+	if len(violations) == 0 {
+		fmt.Printf("Gate passed: session %s is within policy\n", current.ID)
+		return nil
+	}
+	lines := make([]string, 0, len(violations))
+	for _, v := range violations {
+		fmt.Printf("  FAIL %s\n", v)
+		lines = append(lines, fmt.Sprint(v))
+	}
+	s := chatops.Summary{Project: cfg.Project, RunID: current.ID,
+		Verdict: fmt.Sprintf("%d gate violation(s)", len(violations)), Lines: lines}
+	if len(earlier) > 0 {
+		s.CostDeltaUSD = gate.EstimateCostUSD(current) - gate.EstimateCostUSD(earlier[0])
+	}
+	slackNotify(cfg, s)
+	return exitWithCode(fmt.Errorf("%d gate violations in session %s", len(violations), current.ID), 1)
+}
+
+// applyPricing installs the config's per-model price overrides into
+// the cost calculations.
+func applyPricing(cfg *config.Config) {
+	if len(cfg.Pricing) == 0 {
+		return
+	}
+	prices := make(map[string]gate.ModelPrice, len(cfg.Pricing))
+	for prefix, p := range cfg.Pricing {
+		prices[prefix] = gate.ModelPrice{
+			PromptPerMTok:     p.InputPerMTok,
+			CompletionPerMTok: p.OutputPerMTok,
+			PerCall:           p.PerCall,
+		}
+	}
+	gate.SetPriceOverrides(prices)
+}
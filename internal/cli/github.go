@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// emitGitHubSummary publishes the run outcome to GitHub Actions when
+// running inside it: a markdown job summary appended to
+// $GITHUB_STEP_SUMMARY and step outputs appended to $GITHUB_OUTPUT
+// (the file mechanism that replaced the deprecated ::set-output
+// workflow command). Outside Actions it does nothing; failures only
+// warn, since the run itself already succeeded or failed on its own.
+func emitGitHubSummary(sum *runSummary) {
+	if os.Getenv("GITHUB_ACTIONS") != "true" {
+		return
+	}
+	if path := os.Getenv("GITHUB_STEP_SUMMARY"); path != "" {
+		if err := appendToFile(path, githubSummaryMarkdown(sum)); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: writing job summary: %v\n", err)
+		}
+	}
+	if path := os.Getenv("GITHUB_OUTPUT"); path != "" {
+		if err := appendToFile(path, githubOutputs(sum)); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: writing step outputs: %v\n", err)
+		}
+	}
+}
+
+// githubSummaryMarkdown renders the run summary as job-summary
+// markdown: outcome table, regressions and failure categories.
+func githubSummaryMarkdown(sum *runSummary) string {
+	var b strings.Builder
+	verdict := "✅ passing"
+	if sum.failed() {
+		verdict = "❌ failing"
+	}
+	fmt.Fprintf(&b, "## regrada: %s (score %.2f)\n\n", verdict, sum.Score)
+	b.WriteString("| Passed | Failed | Infra | Skipped | Flaky | Regressions |\n")
+	b.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+	fmt.Fprintf(&b, "| %d | %d | %d | %d | %d | %d |\n\n",
+		sum.Passed, sum.Failed, sum.Infra, sum.Skipped, sum.Flaky, len(sum.Regressions))
+	if len(sum.Regressions) > 0 {
+		b.WriteString("### Regressions\n\n")
+		for _, name := range sum.Regressions {
+			fmt.Fprintf(&b, "- `%s` was passing in the baseline but now fails\n", name)
+		}
+		b.WriteString("\n")
+	}
+	if len(sum.ByCategory) > 0 {
+		b.WriteString("### Failures by category\n\n")
+		for _, cat := range triageOrder {
+			tests := sum.ByCategory[cat]
+			if len(tests) == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "- **%s**: %s\n", cat, strings.Join(tests, ", "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// githubOutputs renders key=value step outputs so later workflow steps
+// can branch on the run outcome.
+func githubOutputs(sum *runSummary) string {
+	passed := "true"
+	if sum.failed() {
+		passed = "false"
+	}
+	return fmt.Sprintf("passed=%s\nscore=%.2f\nfailed=%d\nregressions=%d\n",
+		passed, sum.Score, sum.Failed, len(sum.Regressions))
+}
+
+// appendToFile appends content to path, creating it if needed, as the
+// GitHub Actions file-based commands expect.
+func appendToFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(content)
+	return err
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/regrada-ai/regrada/internal/scaffold"
+)
+
+var initCmd = &Command{
+	Name:    "init",
+	Summary: "Scaffold regrada configuration for this project",
+	Usage:   "[flags] [dir]",
+}
+
+func init() {
+	initCmd.Run = runInit
+	register(initCmd)
+}
+
+func runInit(ctx *Context, args []string) error {
+	fs := newFlagSet(initCmd)
+	force := fs.Bool("force", false, "overwrite existing files")
+	templateFlag := fs.String("template", "", "preset suite to scaffold ("+strings.Join(scaffold.TemplateNames(), "|")+")")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	var tmpl *scaffold.Template
+	if *templateFlag != "" {
+		var err error
+		tmpl, err = scaffold.LookupTemplate(*templateFlag)
+		if err != nil {
+			return err
+		}
+	}
+	dir := "."
+	if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	} else if fs.NArg() > 1 {
+		return usageError(initCmd, "at most one directory expected")
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+
+	proj := scaffold.Detect(dir)
+	if proj.Language != "" {
+		fmt.Printf("Detected %s project", proj.Language)
+		if len(proj.Providers) > 0 {
+			fmt.Printf(" using %s", strings.Join(proj.Providers, ", "))
+		}
+		fmt.Println()
+	}
+
+	written, err := scaffold.Write(scaffold.Options{Dir: dir, Project: proj, Force: *force, Template: tmpl})
+	if err != nil {
+		return err
+	}
+	if len(written) == 0 {
+		fmt.Println("Nothing to do: configuration already exists (use --force to overwrite)")
+		return nil
+	}
+	for _, f := range written {
+		fmt.Printf("  created %s\n", f)
+	}
+	fmt.Println("\nNext steps:")
+	if proj.RunHint != "" {
+		fmt.Printf("  %s   # record a trace of your app\n", proj.RunHint)
+	}
+	fmt.Println("  regrada baseline update          # record the known-good baseline")
+	fmt.Println("  regrada run                      # run the tests")
+	return nil
+}
@@ -0,0 +1,323 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/regrada-ai/regrada/internal/providers"
+	"github.com/regrada-ai/regrada/internal/trace"
+	"github.com/regrada-ai/regrada/internal/worddiff"
+)
+
+// ANSI styles for the inline diff; disabled when not writing to a
+// terminal so piped output stays clean.
+const (
+	ansiReset = "\x1b[0m"
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiCyan  = "\x1b[36m"
+	ansiDim   = "\x1b[2m"
+	ansiBold  = "\x1b[1m"
+)
+
+// diffSessionsInline renders colorized unified diffs of the matched
+// calls' prompts and responses, paged through $PAGER on a terminal, so
+// small reviews don't need an HTML report.
+func diffSessionsInline(a, b *trace.Session) error {
+	var buf strings.Builder
+	color := isTerminal(os.Stdout)
+	style := func(code, s string) string {
+		if !color {
+			return s
+		}
+		return code + s + ansiReset
+	}
+
+	fmt.Fprintf(&buf, "%s (%d calls) vs %s (%d calls)\n", a.ID, len(a.Calls), b.ID, len(b.Calls))
+	pairs, changed := pairCalls(a, b), 0
+	for _, pr := range pairs {
+		switch {
+		case pr.b == nil:
+			fmt.Fprintf(&buf, "\n%s\n", style(ansiRed, fmt.Sprintf("-- call %d %s (removed)", pr.a.Index, pr.key)))
+			changed++
+			continue
+		case pr.a == nil:
+			fmt.Fprintf(&buf, "\n%s\n", style(ansiGreen, fmt.Sprintf("++ call %d %s (added)", pr.b.Index, pr.key)))
+			changed++
+			continue
+		}
+		promptDiff := lineDiff(promptLines(pr.a), promptLines(pr.b))
+		responseDiff := lineDiff(responseLines(pr.a), responseLines(pr.b))
+		if !hasChanges(promptDiff) && !hasChanges(responseDiff) {
+			continue
+		}
+		changed++
+		fmt.Fprintf(&buf, "\n%s\n", style(ansiCyan, "== "+pr.key))
+		if hasChanges(promptDiff) {
+			fmt.Fprintf(&buf, "%s\n", style(ansiCyan, "-- prompt"))
+			writeDiff(&buf, promptDiff, style)
+		}
+		if hasChanges(responseDiff) {
+			fmt.Fprintf(&buf, "%s\n", style(ansiCyan, "-- response"))
+			writeDiff(&buf, responseDiff, style)
+		}
+	}
+	if changed == 0 {
+		buf.WriteString("no differences\n")
+	}
+	return page(buf.String())
+}
+
+// callPair is two calls matched by endpoint and order; one side is nil
+// for added or removed calls.
+type callPair struct {
+	key  string
+	a, b *trace.Call
+}
+
+// pairCalls matches calls between two sessions by endpoint in order of
+// occurrence, the same pairing the summary diff uses.
+func pairCalls(a, b *trace.Session) []callPair {
+	byEndpoint := func(s *trace.Session) (map[string][]*trace.Call, []string) {
+		m := map[string][]*trace.Call{}
+		var order []string
+		for i := range s.Calls {
+			c := &s.Calls[i]
+			key := c.Method + " " + stripQuery(c.URL)
+			if _, seen := m[key]; !seen {
+				order = append(order, key)
+			}
+			m[key] = append(m[key], c)
+		}
+		return m, order
+	}
+	aCalls, order := byEndpoint(a)
+	bCalls, bOrder := byEndpoint(b)
+	for _, key := range bOrder {
+		if _, ok := aCalls[key]; !ok {
+			order = append(order, key)
+		}
+	}
+	var pairs []callPair
+	for _, key := range order {
+		as, bs := aCalls[key], bCalls[key]
+		n := len(as)
+		if len(bs) > n {
+			n = len(bs)
+		}
+		for i := 0; i < n; i++ {
+			pr := callPair{key: key}
+			if i < len(as) {
+				pr.a = as[i]
+			}
+			if i < len(bs) {
+				pr.b = bs[i]
+			}
+			pairs = append(pairs, pr)
+		}
+	}
+	return pairs
+}
+
+// promptLines renders a call's request body for diffing, indented when
+// it is JSON.
+func promptLines(c *trace.Call) []string {
+	return textLines(c.Request)
+}
+
+// responseLines prefers the parsed response text (the part a reviewer
+// cares about) and falls back to the indented response body.
+func responseLines(c *trace.Call) []string {
+	if c.Provider != "" {
+		if p, err := providers.ByName(c.Provider); err == nil {
+			if info, err := p.ParseResponse(c.Response); err == nil && info.Text != "" {
+				return strings.Split(info.Text, "\n")
+			}
+		}
+	}
+	return textLines(c.Response)
+}
+
+func textLines(raw []byte) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, raw, "", "  "); err == nil {
+		raw = indented.Bytes()
+	}
+	return strings.Split(strings.TrimRight(string(raw), "\n"), "\n")
+}
+
+// diffLine is one line of a unified diff: op is ' ', '-' or '+'.
+type diffLine struct {
+	op   byte
+	text string
+}
+
+func hasChanges(lines []diffLine) bool {
+	for _, l := range lines {
+		if l.op != ' ' {
+			return true
+		}
+	}
+	return false
+}
+
+// lineDiff computes a line-level unified diff via longest common
+// subsequence. Prompts and responses are small enough that the
+// quadratic table is irrelevant.
+func lineDiff(a, b []string) []diffLine {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	var out []diffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, diffLine{'-', a[i]})
+			i++
+		default:
+			out = append(out, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		out = append(out, diffLine{'+', b[j]})
+	}
+	return out
+}
+
+// writeDiff emits the diff with colors, collapsing long unchanged runs
+// to three lines of context on each side.
+func writeDiff(w io.Writer, lines []diffLine, style func(code, s string) string) {
+	const context = 3
+	keep := make([]bool, len(lines))
+	for i, l := range lines {
+		if l.op == ' ' {
+			continue
+		}
+		for j := i - context; j <= i+context; j++ {
+			if j >= 0 && j < len(lines) {
+				keep[j] = true
+			}
+		}
+	}
+	skipping := false
+	for i := 0; i < len(lines); i++ {
+		l := lines[i]
+		if !keep[i] {
+			if !skipping {
+				fmt.Fprintf(w, "%s\n", style(ansiDim, "  ..."))
+				skipping = true
+			}
+			continue
+		}
+		skipping = false
+		switch l.op {
+		case '-':
+			// An isolated -/+ pair is a changed line: highlight the
+			// words that differ instead of repainting the whole line.
+			if lonePair(lines, i) {
+				renderChangedPair(w, l.text, lines[i+1].text, style)
+				i++
+				continue
+			}
+			fmt.Fprintf(w, "%s\n", style(ansiRed, "- "+l.text))
+		case '+':
+			fmt.Fprintf(w, "%s\n", style(ansiGreen, "+ "+l.text))
+		default:
+			fmt.Fprintf(w, "  %s\n", l.text)
+		}
+	}
+}
+
+// lonePair reports whether lines[i] starts a single removed line
+// followed by a single added one — the shape of an in-place edit.
+func lonePair(lines []diffLine, i int) bool {
+	if i+1 >= len(lines) || lines[i+1].op != '+' {
+		return false
+	}
+	if i > 0 && lines[i-1].op == '-' {
+		return false
+	}
+	return i+2 >= len(lines) || lines[i+2].op != '+'
+}
+
+// renderChangedPair renders an in-place edit as a -/+ line pair with
+// the words that actually changed emphasized in bold, so one-word
+// drift stands out inside a long line.
+func renderChangedPair(w io.Writer, old, new string, style func(code, s string) string) {
+	ops := worddiff.Diff(old, new)
+	var minus, plus strings.Builder
+	minus.WriteString(style(ansiRed, "- "))
+	plus.WriteString(style(ansiGreen, "+ "))
+	for _, op := range ops {
+		switch op.Kind {
+		case ' ':
+			minus.WriteString(style(ansiRed, op.Text))
+			plus.WriteString(style(ansiGreen, op.Text))
+		case '-':
+			minus.WriteString(style(ansiBold+ansiRed, op.Text))
+		case '+':
+			plus.WriteString(style(ansiBold+ansiGreen, op.Text))
+		}
+	}
+	fmt.Fprintln(w, minus.String())
+	fmt.Fprintln(w, plus.String())
+}
+
+// isTerminal reports whether f is attached to a terminal.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// page writes text through $PAGER (default less -R) when on a
+// terminal, and straight to stdout otherwise.
+func page(text string) error {
+	if !isTerminal(os.Stdout) {
+		_, err := io.WriteString(os.Stdout, text)
+		return err
+	}
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		pager = "less -R"
+	}
+	parts := strings.Fields(pager)
+	cmd := exec.Command(parts[0], parts[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		// No pager available: fall back to plain output.
+		_, werr := io.WriteString(os.Stdout, text)
+		return werr
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/regrada-ai/regrada/internal/atomicfile"
+	"github.com/regrada-ai/regrada/internal/evals"
+)
+
+// JUnit XML as consumed by Jenkins, GitLab and CircleCI: a testsuites
+// root with one testsuite per regrada suite. Only the elements those
+// systems read are emitted.
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Time     float64     `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitVerdict `xml:"failure,omitempty"`
+	Error     *junitVerdict `xml:"error,omitempty"`
+	Skipped   *junitVerdict `xml:"skipped,omitempty"`
+}
+
+type junitVerdict struct {
+	Message string `xml:"message,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+// writeJUnit renders the run results as a JUnit XML file at path.
+func writeJUnit(res *evals.Results, path string) error {
+	suite := junitSuite{Name: res.Suite, Tests: len(res.Tests), Time: res.Duration}
+	for _, tr := range res.Tests {
+		c := junitCase{Name: tr.Name, ClassName: res.Suite, Time: tr.Duration}
+		switch tr.Status {
+		case evals.StatusFail:
+			suite.Failures++
+			c.Failure = &junitVerdict{Message: failedCheckSummary(tr), Body: checkDetails(tr)}
+		case evals.StatusError, evals.StatusInfraError:
+			suite.Errors++
+			c.Error = &junitVerdict{Message: tr.Status, Body: checkDetails(tr)}
+		case evals.StatusSkipped, evals.StatusXFail:
+			suite.Skipped++
+			c.Skipped = &junitVerdict{Message: tr.Reason}
+		}
+		suite.Cases = append(suite.Cases, c)
+	}
+	data, err := xml.MarshalIndent(junitSuites{Suites: []junitSuite{suite}}, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append([]byte(xml.Header), data...)
+	return atomicfile.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// failedCheckSummary names the checks that failed, for the failure
+// message attribute.
+func failedCheckSummary(tr evals.TestResult) string {
+	var names []string
+	for _, c := range tr.Checks {
+		if !c.Passed {
+			names = append(names, c.Name)
+		}
+	}
+	if len(names) == 0 {
+		if tr.Reason != "" {
+			return tr.Reason
+		}
+		return "test failed"
+	}
+	return "failed checks: " + strings.Join(names, ", ")
+}
+
+// checkDetails renders one line per check for the element body, so the
+// CI test report explains the verdict without opening regrada.
+func checkDetails(tr evals.TestResult) string {
+	var b strings.Builder
+	for _, c := range tr.Checks {
+		state := "pass"
+		if !c.Passed {
+			state = "fail"
+		}
+		b.WriteString(state + ": " + c.Name)
+		if c.Message != "" {
+			b.WriteString(" — " + c.Message)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/providers"
+	"github.com/regrada-ai/regrada/internal/proxy"
+	"github.com/regrada-ai/regrada/internal/store"
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+var loadCmd = &Command{
+	Name:    "load",
+	Summary: "Replay a session's request pattern under load",
+	Usage:   "--session <id> [--rps n] [--duration d]",
+}
+
+func init() {
+	loadCmd.Run = runLoad
+	register(loadCmd)
+}
+
+// runLoad replays the request pattern of a recorded session against
+// the built-in replay server at a fixed rate, measuring how the stack
+// behaves under load without real provider costs.
+func runLoad(ctx *Context, args []string) error {
+	fs := newFlagSet(loadCmd)
+	sessionFlag := fs.String("session", "", "session to replay (default: latest)")
+	rps := fs.Float64("rps", 10, "requests per second to send")
+	duration := fs.Duration("duration", 30*time.Second, "how long to keep sending")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError(loadCmd, "unexpected argument %q", fs.Arg(0))
+	}
+	if *rps <= 0 {
+		return usageError(loadCmd, "--rps must be positive")
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+
+	var session *trace.Session
+	if *sessionFlag != "" {
+		session, err = trace.Find(st.SessionsDir(), *sessionFlag)
+	} else {
+		var path string
+		path, err = store.LatestFile(st.SessionsDir(), "*.json")
+		if err == nil && path == "" {
+			err = fmt.Errorf("no sessions in %s; trace a run first", st.SessionsDir())
+		}
+		if err == nil {
+			session, err = trace.Load(path)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(session.Calls) == 0 {
+		return fmt.Errorf("session %s has no calls to replay", session.ID)
+	}
+
+	p := proxy.New(proxy.Options{Replay: proxy.NewReplayer(session)})
+	base, err := p.Start()
+	if err != nil {
+		return fmt.Errorf("starting replay server: %w", err)
+	}
+	defer p.Close()
+
+	targets, err := loadTargets(session, base)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Replaying %d request patterns from %s at %.0f rps for %s\n",
+		len(targets), session.ID, *rps, *duration)
+
+	var (
+		mu        sync.Mutex
+		latencies []float64
+		errors    int
+		wg        sync.WaitGroup
+	)
+	client := &http.Client{Timeout: 30 * time.Second}
+	interval := time.Duration(float64(time.Second) / *rps)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	deadline := time.After(*duration)
+	sent := 0
+
+fire:
+	for i := 0; ; i++ {
+		select {
+		case <-deadline:
+			break fire
+		case <-ticker.C:
+			t := targets[i%len(targets)]
+			sent++
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				start := time.Now()
+				req, err := http.NewRequest(t.method, t.url, bytes.NewReader(t.body))
+				if err == nil {
+					var resp *http.Response
+					resp, err = client.Do(req)
+					if err == nil {
+						io.Copy(io.Discard, resp.Body) //nolint:errcheck // draining
+						resp.Body.Close()
+						if resp.StatusCode >= 400 {
+							err = fmt.Errorf("status %d", resp.StatusCode)
+						}
+					}
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					errors++
+					return
+				}
+				latencies = append(latencies, float64(time.Since(start).Milliseconds()))
+			}()
+		}
+	}
+	wg.Wait()
+
+	fmt.Printf("Sent %d requests (%.1f rps achieved), %d errors\n",
+		sent, float64(sent)/duration.Seconds(), errors)
+	if len(latencies) > 0 {
+		sort.Float64s(latencies)
+		pct := func(p int) float64 { return latencies[(len(latencies)-1)*p/100] }
+		fmt.Printf("Latency: p50 %.0fms, p95 %.0fms, max %.0fms\n",
+			pct(50), pct(95), latencies[len(latencies)-1])
+	}
+	if errors > 0 {
+		return exitWithCode(fmt.Errorf("%d of %d requests failed", errors, sent), 1)
+	}
+	return nil
+}
+
+// loadTarget is one request pattern aimed at the replay server.
+type loadTarget struct {
+	method, url string
+	body        []byte
+}
+
+// loadTargets rewrites each recorded call's upstream URL into the
+// replay server's provider-prefixed form.
+func loadTargets(session *trace.Session, base string) ([]loadTarget, error) {
+	var targets []loadTarget
+	for _, c := range session.Calls {
+		u, err := url.Parse(c.URL)
+		if err != nil {
+			continue
+		}
+		provider := providers.ForHost(u.Host)
+		if provider == nil {
+			continue
+		}
+		targets = append(targets, loadTarget{
+			method: c.Method,
+			url:    base + "/" + provider.Name() + u.Path,
+			body:   append([]byte(nil), c.Request...),
+		})
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("session %s has no calls to a known provider", session.ID)
+	}
+	return targets, nil
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/regrada-ai/regrada/internal/baseline"
+	"github.com/regrada-ai/regrada/internal/evals"
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+var migrateCmd = &Command{
+	Name:    "migrate",
+	Summary: "Upgrade persisted state files to the current schema",
+	Usage:   "[flags]",
+}
+
+func init() {
+	migrateCmd.Run = runMigrate
+	register(migrateCmd)
+}
+
+// runMigrate rewrites every baseline, results and session file in the
+// state directory at the current schema version, keeping a .bak copy
+// of each file it changes. Files already current are left untouched.
+func runMigrate(ctx *Context, args []string) error {
+	fs := newFlagSet(migrateCmd)
+	dryRun := fs.Bool("dry-run", false, "report what would be migrated without writing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError(migrateCmd, "unexpected argument %q", fs.Arg(0))
+	}
+
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+
+	upgraded, current := 0, 0
+	migrate := func(path string, fn func(path string) (bool, error)) error {
+		stale, err := fn(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if !stale {
+			current++
+			return nil
+		}
+		upgraded++
+		if *dryRun {
+			fmt.Printf("  would migrate %s\n", path)
+			return nil
+		}
+		fmt.Printf("  migrated %s (backup at %s.bak)\n", path, path)
+		return nil
+	}
+
+	if path := st.BaselinePath(); fileExists(path) {
+		if err := migrate(path, func(path string) (bool, error) {
+			return migrateBaseline(path, *dryRun)
+		}); err != nil {
+			return err
+		}
+	}
+	resultFiles := []string{st.ResultsPath(), st.ResultsBaselinePath()}
+	history, _ := filepath.Glob(filepath.Join(st.HistoryDir(), "*.json"))
+	resultFiles = append(resultFiles, history...)
+	for _, path := range resultFiles {
+		if !fileExists(path) {
+			continue
+		}
+		if err := migrate(path, func(path string) (bool, error) {
+			return migrateResults(path, *dryRun)
+		}); err != nil {
+			return err
+		}
+	}
+	sessions, _ := filepath.Glob(filepath.Join(st.SessionsDir(), "*.json"))
+	for _, path := range sessions {
+		if err := migrate(path, func(path string) (bool, error) {
+			return migrateSession(path, *dryRun)
+		}); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("%d files migrated, %d already current\n", upgraded, current)
+	return nil
+}
+
+// migrateBaseline upgrades one baseline file, reporting whether it was
+// stale. Unversioned files are stamped with the current version.
+func migrateBaseline(path string, dryRun bool) (bool, error) {
+	b, err := baseline.Load(path)
+	if err != nil {
+		return false, err
+	}
+	if b.Version >= baseline.CurrentVersion {
+		return false, nil
+	}
+	b.Version = baseline.CurrentVersion
+	if dryRun {
+		return true, nil
+	}
+	if err := backupFile(path); err != nil {
+		return false, err
+	}
+	return true, b.Save(path)
+}
+
+func migrateResults(path string, dryRun bool) (bool, error) {
+	r, err := evals.LoadResults(path)
+	if err != nil {
+		return false, err
+	}
+	if r.Schema >= evals.ResultsSchemaVersion {
+		return false, nil
+	}
+	r.Schema = evals.ResultsSchemaVersion
+	if dryRun {
+		return true, nil
+	}
+	if err := backupFile(path); err != nil {
+		return false, err
+	}
+	return true, r.Save(path)
+}
+
+func migrateSession(path string, dryRun bool) (bool, error) {
+	s, err := trace.Load(path)
+	if err != nil {
+		return false, err
+	}
+	if s.Schema >= trace.SchemaVersion {
+		return false, nil
+	}
+	s.Schema = trace.SchemaVersion
+	if dryRun {
+		return true, nil
+	}
+	if err := backupFile(path); err != nil {
+		return false, err
+	}
+	_, err = s.Save(filepath.Dir(path))
+	return true, err
+}
+
+// backupFile copies the original bytes of path to path.bak before a
+// migration overwrites it.
+func backupFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path+".bak", data, 0o644)
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/regrada-ai/regrada/internal/browser"
+	"github.com/regrada-ai/regrada/internal/store"
+)
+
+var openCmd = &Command{
+	Name:    "open",
+	Summary: "Open the most recent HTML report in the browser",
+	Usage:   "[flags] [run-id]",
+}
+
+func init() {
+	openCmd.Run = runOpen
+	register(openCmd)
+}
+
+func runOpen(ctx *Context, args []string) error {
+	fs := newFlagSet(openCmd)
+	printOnly := fs.Bool("print", false, "print the report path instead of opening it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return usageError(openCmd, "at most one run id expected")
+	}
+
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+
+	var target string
+	if fs.NArg() == 1 {
+		runID := fs.Arg(0)
+		target = filepath.Join(st.ReportsDir(), runID+".html")
+		if !fileExists(target) {
+			return fmt.Errorf("no report for run %q; generate one with `regrada report`", runID)
+		}
+	} else {
+		target, err = store.LatestFile(st.ReportsDir(), "*.html")
+		if err != nil {
+			return err
+		}
+		if target == "" {
+			return fmt.Errorf("no reports in %s; generate one with `regrada report`", st.ReportsDir())
+		}
+	}
+
+	if *printOnly {
+		fmt.Println(target)
+		return nil
+	}
+	fmt.Printf("Opening %s\n", target)
+	return browser.Open("file://" + target)
+}
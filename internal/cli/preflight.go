@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/providers"
+)
+
+// preflightProviders pings every provider that has credentials in the
+// environment with a cheap authenticated call, so broken auth or
+// connectivity fails fast with one clear message instead of dozens of
+// confusing test errors.
+func preflightProviders() error {
+	client := &http.Client{Timeout: 10 * time.Second}
+	var failures []string
+	checked := 0
+	for _, p := range providers.All() {
+		keyEnv := p.APIKeyEnv()
+		if keyEnv == "" {
+			continue
+		}
+		key := os.Getenv(keyEnv)
+		if key == "" {
+			continue
+		}
+		url := p.HealthURL()
+		if url == "" {
+			continue
+		}
+		checked++
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", p.Name(), err))
+			continue
+		}
+		p.Authenticate(req, key)
+		resp, err := client.Do(req)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: unreachable: %v", p.Name(), err))
+			continue
+		}
+		resp.Body.Close()
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+			failures = append(failures, fmt.Sprintf("%s: %s — check %s", p.Name(), resp.Status, keyEnv))
+		case resp.StatusCode >= 400:
+			failures = append(failures, fmt.Sprintf("%s: %s from %s", p.Name(), resp.Status, url))
+		default:
+			fmt.Printf("  preflight ok: %s\n", p.Name())
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("provider preflight failed:\n  %s", strings.Join(failures, "\n  "))
+	}
+	if checked == 0 {
+		fmt.Println("  preflight: no provider credentials in the environment, nothing to check")
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/providers"
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+var replayCmd = &Command{
+	Name:    "replay",
+	Summary: "Re-send captured requests to the live provider and diff responses",
+	Usage:   "[flags] <session-id>",
+}
+
+func init() {
+	replayCmd.Run = runReplay
+	register(replayCmd)
+}
+
+func runReplay(ctx *Context, args []string) error {
+	fs := newFlagSet(replayCmd)
+	callsFlag := fs.String("calls", "", "comma-separated call indexes to replay (default: all)")
+	modelFlag := fs.String("model", "", "override the model in replayed requests")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError(replayCmd, "exactly one session id expected")
+	}
+
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	session, err := trace.Find(st.SessionsDir(), fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	indexes, err := parseCallIndexes(*callsFlag)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 120 * time.Second}
+	replayed := 0
+	for i := range session.Calls {
+		call := &session.Calls[i]
+		if indexes != nil && !indexes[call.Index] {
+			continue
+		}
+		replayed++
+		if err := replayCall(client, call, *modelFlag); err != nil {
+			fmt.Printf("call %d: error: %v\n", call.Index, err)
+		}
+	}
+	if replayed == 0 {
+		return fmt.Errorf("no calls matched --calls %q", *callsFlag)
+	}
+	return nil
+}
+
+// replayCall re-sends one captured request and prints how the fresh
+// response differs from the recorded one.
+func replayCall(client *http.Client, call *trace.Call, modelOverride string) error {
+	u, err := url.Parse(call.URL)
+	if err != nil {
+		return err
+	}
+	provider := providers.ForHost(u.Host)
+	if provider == nil {
+		return fmt.Errorf("no provider known for host %s", u.Host)
+	}
+	key := os.Getenv(provider.APIKeyEnv())
+	if key == "" {
+		return fmt.Errorf("%s not set", provider.APIKeyEnv())
+	}
+
+	body := []byte(call.Request)
+	if modelOverride != "" {
+		body, err = overrideModel(body, modelOverride)
+		if err != nil {
+			return fmt.Errorf("overriding model: %w", err)
+		}
+	}
+	req, err := http.NewRequest(call.Method, call.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	provider.Authenticate(req, key)
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	elapsed := time.Since(start)
+
+	fmt.Printf("call %d: %s %s -> %s in %dms (recorded %.0fms)\n",
+		call.Index, call.Method, u.Path, resp.Status, elapsed.Milliseconds(), call.DurationMS)
+	printResponseDiff(provider, []byte(call.Response), respBody)
+	return nil
+}
+
+// printResponseDiff compares recorded and fresh responses at the
+// parsed level (text, tool calls) rather than raw JSON, since ids and
+// timestamps always differ.
+func printResponseDiff(p providers.Provider, recorded, fresh []byte) {
+	oldInfo, errOld := p.ParseResponse(recorded)
+	newInfo, errNew := p.ParseResponse(fresh)
+	if errOld != nil || errNew != nil {
+		fmt.Println("  (responses not comparable; dumping fresh response)")
+		fmt.Printf("  %s\n", truncate(string(fresh), 500))
+		return
+	}
+	if oldInfo.Model != newInfo.Model {
+		fmt.Printf("  model: %s -> %s\n", oldInfo.Model, newInfo.Model)
+	}
+	oldTools := strings.Join(oldInfo.ToolCalls, ",")
+	newTools := strings.Join(newInfo.ToolCalls, ",")
+	if oldTools != newTools {
+		fmt.Printf("  tool calls: [%s] -> [%s]\n", oldTools, newTools)
+	}
+	if oldInfo.Text == newInfo.Text {
+		fmt.Println("  text: unchanged")
+		return
+	}
+	fmt.Printf("  text (recorded): %s\n", truncate(oldInfo.Text, 300))
+	fmt.Printf("  text (fresh):    %s\n", truncate(newInfo.Text, 300))
+}
+
+// overrideModel rewrites the "model" field of a JSON request body.
+func overrideModel(body []byte, model string) ([]byte, error) {
+	var m map[string]any
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, err
+	}
+	m["model"] = model
+	return json.Marshal(m)
+}
+
+// parseCallIndexes parses "3,7" into a set; nil means all calls.
+func parseCallIndexes(spec string) (map[int]bool, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	set := map[int]bool{}
+	for _, part := range strings.Split(spec, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid call index %q", part)
+		}
+		set[n] = true
+	}
+	return set, nil
+}
+
+// truncate shortens s for terminal display.
+func truncate(s string, n int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
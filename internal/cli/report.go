@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/regrada-ai/regrada/internal/evals"
+	"github.com/regrada-ai/regrada/internal/github"
+	"github.com/regrada-ai/regrada/internal/gitlab"
+	"github.com/regrada-ai/regrada/internal/report"
+	"github.com/regrada-ai/regrada/internal/store"
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+var reportCmd = &Command{
+	Name:    "report",
+	Summary: "Render run results into a shareable report",
+	Usage:   "[flags] [run-id]",
+}
+
+func init() {
+	reportCmd.Run = runReport
+	register(reportCmd)
+}
+
+// runReport renders the latest run (or a named historical run) into a
+// standalone HTML file under the reports directory, where `regrada
+// open` and artifact upload find it.
+func runReport(ctx *Context, args []string) error {
+	fs := newFlagSet(reportCmd)
+	format := fs.String("format", "html", "report format (html)")
+	withTrace := fs.Bool("trace", false, "include the latest recorded trace session in the report")
+	githubPR := fs.Bool("github-pr", false, "post the summary as a pull request comment (updated in place) using GITHUB_TOKEN")
+	gitlabMR := fs.Bool("gitlab-mr", false, "post the summary as a merge request note (updated in place) using GITLAB_TOKEN")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "html" {
+		return usageError(reportCmd, "unsupported format %q (supported: html)", *format)
+	}
+	if fs.NArg() > 1 {
+		return usageError(reportCmd, "at most one run id expected")
+	}
+
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	path := st.ResultsPath()
+	if fs.NArg() == 1 {
+		path = filepath.Join(st.HistoryDir(), fs.Arg(0)+".json")
+	}
+	res, err := evals.LoadResults(path)
+	if err != nil {
+		return err
+	}
+
+	d := &report.Data{Results: res}
+	if fileExists(st.ResultsBaselinePath()) {
+		if base, err := evals.LoadResults(st.ResultsBaselinePath()); err == nil {
+			d.Baseline = base
+		}
+	}
+	if *withTrace {
+		sessPath, err := store.LatestFile(st.SessionsDir(), "*.json")
+		if err == nil && sessPath != "" {
+			if s, err := trace.Load(sessPath); err == nil {
+				d.Session = s
+			}
+		}
+	}
+
+	if err := st.EnsureDir(st.ReportsDir()); err != nil {
+		return err
+	}
+	out := filepath.Join(st.ReportsDir(), res.RunID+".html")
+	if err := report.WriteHTML(d, out); err != nil {
+		return err
+	}
+	fmt.Printf("Report written to %s\n", out)
+
+	if *githubPR {
+		client, err := github.NewClientFromEnv()
+		if err != nil {
+			return err
+		}
+		pr, err := github.PRNumberFromEnv()
+		if err != nil {
+			return err
+		}
+		body := github.Marker + "\n" + report.Markdown(d)
+		if err := client.UpsertPRComment(pr, body); err != nil {
+			return err
+		}
+		fmt.Printf("Posted summary to %s#%d\n", client.Repo, pr)
+	}
+	if *gitlabMR {
+		client, err := gitlab.NewClientFromEnv()
+		if err != nil {
+			return err
+		}
+		iid, err := gitlab.MRIIDFromEnv()
+		if err != nil {
+			return err
+		}
+		body := gitlab.Marker + "\n" + report.Markdown(d)
+		if err := client.UpsertMRNote(iid, body); err != nil {
+			return err
+		}
+		fmt.Printf("Posted summary to merge request !%d\n", iid)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/regrada-ai/regrada/internal/baseline"
+	"github.com/regrada-ai/regrada/internal/evals"
+)
+
+var reviewCmd = &Command{
+	Name:    "review",
+	Summary: "Triage detected regressions interactively",
+	Usage:   "",
+}
+
+func init() {
+	reviewCmd.Run = runReview
+	register(reviewCmd)
+}
+
+// runReview walks through each regression in the latest run — prompt,
+// baseline response versus new response, failing checks — and asks the
+// user to triage it. Accepted changes are folded into the baseline;
+// flaky ones go into quarantine; real regressions are left to fail.
+func runReview(ctx *Context, args []string) error {
+	fs := newFlagSet(reviewCmd)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError(reviewCmd, "unexpected argument %q", fs.Arg(0))
+	}
+
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	res, err := evals.LoadResults(st.ResultsPath())
+	if err != nil {
+		return err
+	}
+	if !fileExists(st.ResultsBaselinePath()) {
+		return fmt.Errorf("no results baseline at %s; promote one with `regrada baseline promote` first", st.ResultsBaselinePath())
+	}
+	resBase, err := evals.LoadResults(st.ResultsBaselinePath())
+	if err != nil {
+		return err
+	}
+	q, err := evals.LoadQuarantine(st.QuarantinePath())
+	if err != nil {
+		return err
+	}
+	b, err := baseline.Load(st.BaselinePath())
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var regressions []evals.TestResult
+	for _, tr := range res.Tests {
+		if tr.Status != evals.StatusFail || q.Has(tr.Name) {
+			continue
+		}
+		if bt := resBase.Test(tr.Name); bt != nil && bt.Status == evals.StatusPass {
+			regressions = append(regressions, tr)
+		}
+	}
+	if len(regressions) == 0 {
+		fmt.Println("No regressions to review in the latest run.")
+		return nil
+	}
+
+	in := bufio.NewReader(os.Stdin)
+	var accepted, real, flaky int
+	for i, tr := range regressions {
+		bt := resBase.Test(tr.Name)
+		fmt.Printf("\n[%d/%d] %s\n", i+1, len(regressions), tr.Name)
+		printIndented("prompt", tr.Prompt)
+		printIndented("baseline response", bt.Response)
+		printIndented("new response", tr.Response)
+		fmt.Println("  failing checks:")
+		for _, c := range tr.Checks {
+			if c.Passed {
+				continue
+			}
+			fmt.Printf("    %s: %s\n", c.Name, c.Message)
+		}
+
+		verdict, err := askVerdict(in)
+		if err != nil {
+			return err
+		}
+		switch verdict {
+		case "a":
+			*bt = tr
+			if b != nil {
+				b.Record(tr)
+			}
+			accepted++
+			fmt.Println("  accepted as new behavior")
+		case "r":
+			real++
+			fmt.Println("  kept as a real regression")
+		case "f":
+			q.Add(tr.Name, "marked flaky during review of run "+res.RunID)
+			flaky++
+			fmt.Println("  quarantined as flaky")
+		case "q":
+			fmt.Println("  stopping; remaining regressions left untriaged")
+		}
+		if verdict == "q" {
+			break
+		}
+	}
+
+	if accepted > 0 {
+		if err := resBase.Save(st.ResultsBaselinePath()); err != nil {
+			return err
+		}
+		if b != nil {
+			if err := b.Save(st.BaselinePath()); err != nil {
+				return err
+			}
+		}
+	}
+	if flaky > 0 {
+		if err := q.Save(st.QuarantinePath()); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("\nReviewed: %d accepted, %d real, %d quarantined as flaky.\n", accepted, real, flaky)
+	return nil
+}
+
+// askVerdict prompts until the user picks one of the triage options.
+func askVerdict(in *bufio.Reader) (string, error) {
+	for {
+		fmt.Print("  [a]ccept new behavior / [r]eal regression / [f]laky / [q]uit: ")
+		line, err := in.ReadString('\n')
+		if err != nil {
+			return "", fmt.Errorf("reading verdict: %w", err)
+		}
+		switch v := strings.ToLower(strings.TrimSpace(line)); v {
+		case "a", "r", "f", "q":
+			return v, nil
+		}
+	}
+}
+
+// printIndented prints a possibly multi-line value under a label,
+// indented so the review transcript stays scannable.
+func printIndented(label, value string) {
+	fmt.Printf("  %s:\n", label)
+	if value == "" {
+		fmt.Println("    (empty)")
+		return
+	}
+	for _, line := range strings.Split(strings.TrimRight(value, "\n"), "\n") {
+		fmt.Println("    " + line)
+	}
+}
@@ -0,0 +1,803 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/baseline"
+	"github.com/regrada-ai/regrada/internal/config"
+	"github.com/regrada-ai/regrada/internal/evals"
+	"github.com/regrada-ai/regrada/internal/gate"
+	"github.com/regrada-ai/regrada/internal/gitlab"
+	"github.com/regrada-ai/regrada/internal/hooks"
+	"github.com/regrada-ai/regrada/internal/judge"
+	"github.com/regrada-ai/regrada/internal/store"
+	"github.com/regrada-ai/regrada/internal/trace"
+	"github.com/regrada-ai/regrada/internal/webhook"
+)
+
+var runCmd = &Command{
+	Name:    "run",
+	Summary: "Run the test suite and compare against the baseline",
+	Usage:   "[flags]",
+}
+
+func init() {
+	runCmd.Run = runRun
+	register(runCmd)
+}
+
+func runRun(ctx *Context, args []string) error {
+	fs := newFlagSet(runCmd)
+	allProjects := fs.Bool("all-projects", false, "run every project listed in the workspace file")
+	strict := fs.Bool("strict", false, "treat tests using unknown checks as errors")
+	dryRun := fs.Bool("dry-run", false, "print what would run without running it")
+	artifacts := fs.Bool("artifacts", false, "write per-test artifacts (prompt, response, checks) for debugging")
+	reRecord := fs.Bool("re-record", false, "refresh test cassettes instead of replaying them")
+	preflight := fs.Bool("preflight", false, "ping configured providers before running and fail fast on auth errors")
+	filter := fs.String("filter", "", "run only tests whose name matches the glob pattern")
+	tagsFlag := fs.String("tags", "", "run only tests carrying one of these comma-separated tags")
+	detectFlaky := fs.Int("detect-flaky", 0, "rerun each failing test N times to separate flaky from deterministic failures")
+	compareTo := fs.String("compare-to", "", "compare against a historical run (run id, prefix, or date) instead of the baseline")
+	requireBaseline := fs.Bool("require-baseline", false, "fail unless every test was compared against a baseline entry")
+	summarize := fs.Bool("summarize", false, "describe behavioral changes in prose via the judge model (sends redacted responses)")
+	profile := fs.String("profile", "", "apply the named run profile from the config as flag defaults")
+	repeat := fs.Int("repeat", 0, "run each test N times and gate on its pass rate (tests with their own repeat: keep it)")
+	output := fs.String("output", "", "additional report format to write (junit, gitlab)")
+	serverFlag := fs.String("server", "", "regrada server URL to publish results and the recorded session to")
+	noLock := fs.Bool("no-lock", false, "skip the project run lock (risks corrupting state)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError(runCmd, "unexpected argument %q", fs.Arg(0))
+	}
+
+	if *output != "" && *output != "junit" && *output != "gitlab" {
+		return usageError(runCmd, "unsupported output format %q (supported: junit, gitlab)", *output)
+	}
+	if *output == "" && gitlab.InCI() {
+		// Inside GitLab CI the pipeline can always consume these
+		// artifacts, so write them without being asked.
+		*output = "gitlab"
+	}
+	if *preflight && !*dryRun {
+		if err := preflightProviders(); err != nil {
+			return err
+		}
+	}
+	opts := runOptions{Strict: *strict, Artifacts: *artifacts, ReRecord: *reRecord, Filter: *filter, Tags: splitList(*tagsFlag), DetectFlaky: *detectFlaky, CompareTo: *compareTo, Repeat: *repeat, Output: *output, RequireBaseline: *requireBaseline, Summarize: *summarize, Profile: *profile}
+	if *allProjects {
+		release, err := acquireRunLock(ctx, *noLock)
+		if err != nil {
+			return err
+		}
+		defer release()
+		return runAllProjects(ctx, opts)
+	}
+	cfg, err := ctx.Config()
+	if err != nil {
+		return err
+	}
+	if opts, err = applyProfile(cfg, opts); err != nil {
+		return err
+	}
+	if *dryRun {
+		return dryRunProject(cfg)
+	}
+	release, err := acquireRunLock(ctx, *noLock)
+	if err != nil {
+		return err
+	}
+	defer release()
+	sum, err := runProject(cfg, opts)
+	if err != nil {
+		return err
+	}
+	sum.print("")
+	emitGitHubSummary(sum)
+	if *serverFlag != "" {
+		pushToServer(cfg, *serverFlag)
+	}
+	if sum.BaselineStatus == evals.BaselineMissing && !sum.failed() {
+		fmt.Println("No baseline yet, so regressions cannot be detected; save this run as the baseline with `regrada baseline promote`.")
+	}
+	if opts.RequireBaseline && sum.BaselineStatus != evals.BaselineOK {
+		return exitWithCode(fmt.Errorf("baseline is %s; promote a known-good run with `regrada baseline promote`", sum.BaselineStatus), 1)
+	}
+	if len(sum.Regressions) > 0 && cfg.Notify.SlackWebhook != "" {
+		approved, err := chatOpsApproval(cfg, sum)
+		if err != nil {
+			return err
+		}
+		if approved {
+			// The diff was accepted as intended behavior; the new
+			// baseline makes the gates below moot for this run.
+			publishCommitStatus(cfg, sum, false)
+			return nil
+		}
+	}
+	publishCommitStatus(cfg, sum, gateFailed(cfg, opts, sum))
+	if sum.failed() && len(sum.Regressions) == 0 {
+		// Regressions were already posted by the ChatOps flow above;
+		// plain failures get a notification of their own.
+		slackNotify(cfg, runFailureSummary(cfg, sum))
+	}
+	if t := cfg.Gate.Threshold; t > 0 && sum.Score < t {
+		return exitWithCode(fmt.Errorf("score %.2f below gate threshold %.2f", sum.Score, t), 1)
+	}
+	if err := costGate(cfg, sum); err != nil {
+		return err
+	}
+	if sum.failed() {
+		return exitWithCode(fmt.Errorf("%d of %d tests failed", sum.Failed, sum.Passed+sum.Failed), 1)
+	}
+	if cfg.Gate.FailOnInfra && sum.Infra > 0 {
+		return exitWithCode(fmt.Errorf("%d tests hit provider infrastructure errors", sum.Infra), 1)
+	}
+	return nil
+}
+
+// runAllProjects runs every project in the workspace and aggregates
+// the outcome so a monorepo gets one consolidated CI result.
+func runAllProjects(ctx *Context, opts runOptions) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	ws, ok, err := config.FindWorkspace(cwd)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("no %s found; --all-projects needs a workspace file", config.WorkspaceFileName)
+	}
+
+	var total runSummary
+	anyFailed := false
+	for _, dir := range ws.ProjectDirs() {
+		cfg, err := config.LoadDir(dir)
+		if err != nil {
+			return fmt.Errorf("project %s: %w", dir, err)
+		}
+		rel, _ := filepath.Rel(ws.Root, dir)
+		fmt.Printf("== %s ==\n", rel)
+		sum, err := runProject(cfg, opts)
+		if err != nil {
+			return fmt.Errorf("project %s: %w", rel, err)
+		}
+		sum.print("  ")
+		total.Passed += sum.Passed
+		total.Failed += sum.Failed
+		total.Infra += sum.Infra
+		total.Skipped += sum.Skipped
+		total.XFailed += sum.XFailed
+		total.Flaky += sum.Flaky
+		total.BudgetExceeded = total.BudgetExceeded || sum.BudgetExceeded
+		total.Regressions = append(total.Regressions, sum.Regressions...)
+		for cat, tests := range sum.ByCategory {
+			if total.ByCategory == nil {
+				total.ByCategory = map[string][]string{}
+			}
+			total.ByCategory[cat] = append(total.ByCategory[cat], tests...)
+		}
+		if opts.RequireBaseline && sum.BaselineStatus != evals.BaselineOK {
+			fmt.Printf("  baseline is %s in %s\n", sum.BaselineStatus, rel)
+			anyFailed = true
+		}
+		if err := costGate(cfg, sum); err != nil {
+			fmt.Printf("  %v\n", err)
+			anyFailed = true
+		}
+		if sum.failed() {
+			anyFailed = true
+		}
+	}
+	fmt.Printf("== workspace ==\n")
+	total.print("  ")
+	if anyFailed {
+		return exitWithCode(fmt.Errorf("failures in workspace"), 1)
+	}
+	return nil
+}
+
+// dryRunProject resolves config, expands the suite and prints what a
+// run would execute — tests, checks and an estimated prompt token
+// budget — without evaluating anything. Useful for validating big
+// suite changes before burning tokens.
+func dryRunProject(cfg *config.Config) error {
+	suite, err := evals.LoadSuite(cfg.SuitePath())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Suite %s (%s): %d tests\n", suite.Name, cfg.SuitePath(), len(suite.Tests))
+	var totalTokens int
+	for i := range suite.Tests {
+		t := &suite.Tests[i]
+		prompt, err := suite.ResolvePrompt(t)
+		if err != nil {
+			return err
+		}
+		// Rough budget: ~4 characters per token, good enough to spot a
+		// suite change that would 10x the bill.
+		tokens := (len(prompt) + 3) / 4
+		totalTokens += tokens
+		fmt.Printf("  %s (~%d prompt tokens)\n", t.Name, tokens)
+		for _, spec := range t.Checks {
+			fmt.Printf("    check: %s\n", spec)
+		}
+	}
+	fmt.Printf("Estimated prompt budget: ~%d tokens across %d tests\n", totalTokens, len(suite.Tests))
+	fmt.Println("Dry run: nothing was executed.")
+	return nil
+}
+
+// runOptions carries the flag-controlled knobs of one run invocation.
+type runOptions struct {
+	Strict      bool
+	Artifacts   bool
+	ReRecord    bool
+	Filter      string
+	Tags        []string
+	DetectFlaky int
+	// CompareTo names a historical run to diff against instead of the
+	// canonical baseline: a run id, a run-id prefix, or a date.
+	CompareTo string
+	Repeat    int
+	// Output names an additional report format to write ("junit").
+	Output string
+	// RequireBaseline fails the run unless every test was compared
+	// against a baseline entry.
+	RequireBaseline bool
+	// Summarize asks the judge model to describe status changes in
+	// prose for the PR comment and HTML report.
+	Summarize bool
+	// Profile names a run profile from the config, applied as defaults
+	// for whatever the flags above left unset.
+	Profile string
+}
+
+// applyProfile fills the options the command line left at their zero
+// values from the named profile. Unknown names are an error so a typo
+// does not silently run the wrong preset.
+func applyProfile(cfg *config.Config, opts runOptions) (runOptions, error) {
+	if opts.Profile == "" {
+		return opts, nil
+	}
+	p, ok := cfg.Profiles[opts.Profile]
+	if !ok {
+		known := make([]string, 0, len(cfg.Profiles))
+		for name := range cfg.Profiles {
+			known = append(known, name)
+		}
+		sort.Strings(known)
+		return opts, fmt.Errorf("no profile %q in config (have: %s)", opts.Profile, strings.Join(known, ", "))
+	}
+	if opts.Filter == "" {
+		opts.Filter = p.Filter
+	}
+	if len(opts.Tags) == 0 {
+		opts.Tags = p.Tags
+	}
+	if opts.Repeat == 0 {
+		opts.Repeat = p.Repeat
+	}
+	if opts.DetectFlaky == 0 {
+		opts.DetectFlaky = p.DetectFlaky
+	}
+	opts.Strict = opts.Strict || p.Strict
+	opts.Artifacts = opts.Artifacts || p.Artifacts
+	opts.RequireBaseline = opts.RequireBaseline || p.RequireBaseline
+	return opts, nil
+}
+
+// runSummary is the printable outcome of one project run.
+type runSummary struct {
+	Passed int
+	Failed int
+	// Infra counts tests hitting provider infrastructure errors; kept
+	// out of Failed and regression counts.
+	Infra int
+	// Skipped counts tests that never ran: skip: true, or cut off by
+	// the suite's wall-clock budget.
+	Skipped int
+	// XFailed counts expected failures (xfail: true), gating neither.
+	XFailed int
+	// Flaky counts failures that did not reproduce on reruns.
+	Flaky int
+	// BudgetExceeded records that skips came from an exhausted
+	// max_duration budget.
+	BudgetExceeded bool
+	Score          float64
+	Regressions    []string
+	// Unimplemented lists check specs the registry does not know,
+	// deduplicated, so strict-mode errors are actionable.
+	Unimplemented []string
+	// ByCategory maps failure categories (correctness, safety, cost,
+	// latency, flaky) to the tests that failed a check in them.
+	ByCategory map[string][]string
+	// BaselineStatus records what the run was compared against:
+	// evals.BaselineMissing, BaselineStale or BaselineOK.
+	BaselineStatus string
+	// CostUSD is the estimated provider cost of the traffic behind the
+	// run.
+	CostUSD float64
+	// BaselineCostUSD is the baseline run's estimated cost, 0 when no
+	// results baseline exists.
+	BaselineCostUSD float64
+	// TopCosts lists the most expensive calls of the run's session,
+	// formatted for display when a cost gate trips.
+	TopCosts []string
+}
+
+func (s *runSummary) failed() bool { return s.Failed > 0 }
+
+// triageOrder fixes the display order of failure categories, most
+// actionable first.
+var triageOrder = []string{
+	evals.CategoryCorrectness,
+	evals.CategorySafety,
+	evals.CategoryCost,
+	evals.CategoryLatency,
+	evals.CategoryFlaky,
+}
+
+func (s *runSummary) print(indent string) {
+	fmt.Printf("%s%d passed, %d failed (score %.2f)", indent, s.Passed, s.Failed, s.Score)
+	if s.Infra > 0 {
+		fmt.Printf(", %d infra errors", s.Infra)
+	}
+	if s.Skipped > 0 {
+		fmt.Printf(", %d skipped", s.Skipped)
+		if s.BudgetExceeded {
+			fmt.Print(" (suite budget exhausted)")
+		}
+	}
+	if s.XFailed > 0 {
+		fmt.Printf(", %d expected failures", s.XFailed)
+	}
+	if s.Flaky > 0 {
+		fmt.Printf(", %d flaky", s.Flaky)
+	}
+	if len(s.Regressions) > 0 {
+		fmt.Printf(", %d regressions", len(s.Regressions))
+	}
+	if s.CostUSD > 0 {
+		fmt.Printf(", est. cost $%.4f", s.CostUSD)
+	}
+	fmt.Println()
+	for _, name := range s.Regressions {
+		fmt.Printf("%s  regression: %s was passing in baseline but now fails\n", indent, name)
+	}
+	if len(s.Unimplemented) > 0 {
+		fmt.Printf("%sUnimplemented checks: %s\n", indent, strings.Join(s.Unimplemented, ", "))
+	}
+	if len(s.ByCategory) == 0 {
+		return
+	}
+	fmt.Printf("%sFailures by category:\n", indent)
+	for _, cat := range triageOrder {
+		tests := s.ByCategory[cat]
+		if len(tests) == 0 {
+			continue
+		}
+		shown := tests
+		const maxShown = 3
+		suffix := ""
+		if len(shown) > maxShown {
+			suffix = fmt.Sprintf(", +%d more", len(shown)-maxShown)
+			shown = shown[:maxShown]
+		}
+		fmt.Printf("%s  %-12s %d (%s%s)\n", indent, cat, len(tests), strings.Join(shown, ", "), suffix)
+	}
+}
+
+// addCategories records which categories a failing test tripped.
+func (s *runSummary) addCategories(tr evals.TestResult) {
+	seen := map[string]bool{}
+	for _, c := range tr.Checks {
+		if c.Passed || seen[c.Category] {
+			continue
+		}
+		seen[c.Category] = true
+		if s.ByCategory == nil {
+			s.ByCategory = map[string][]string{}
+		}
+		s.ByCategory[c.Category] = append(s.ByCategory[c.Category], tr.Name)
+	}
+}
+
+// resolveCompareRun finds the historical run named by a --compare-to
+// spec: an exact run id, a run-id prefix, or a date (2006-01-02 or
+// 20060102). A prefix or date resolves to its most recent matching run,
+// so "--compare-to 2026-08-19" means "the last run from that day".
+func resolveCompareRun(st *store.Store, spec string) (*evals.Results, error) {
+	exact := filepath.Join(st.HistoryDir(), spec+".json")
+	if fileExists(exact) {
+		return evals.LoadResults(exact)
+	}
+	prefix := spec
+	if t, err := time.Parse("2006-01-02", spec); err == nil {
+		prefix = t.Format("20060102")
+	}
+	matches, _ := filepath.Glob(filepath.Join(st.HistoryDir(), prefix+"*.json"))
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no run in history matches %q", spec)
+	}
+	// Run ids start with a UTC timestamp, so lexical order is
+	// chronological order.
+	sort.Strings(matches)
+	return evals.LoadResults(matches[len(matches)-1])
+}
+
+// baselineStatus classifies what a run was compared against: missing
+// when no baseline of either kind exists, stale when one exists but
+// lacks some of the run's tests, ok when every test had an entry.
+func baselineStatus(res *evals.Results, resBase *evals.Results, b *baseline.Baseline) string {
+	if resBase == nil && b == nil {
+		return evals.BaselineMissing
+	}
+	for _, tr := range res.Tests {
+		if resBase != nil {
+			if resBase.Test(tr.Name) == nil {
+				return evals.BaselineStale
+			}
+		} else if _, ok := b.Tests[tr.Name]; !ok {
+			return evals.BaselineStale
+		}
+	}
+	return evals.BaselineOK
+}
+
+// baselineKey returns the name the per-branch/per-environment baseline
+// is stored under: the configured env when set, otherwise the current
+// git branch.
+func baselineKey(cfg *config.Config) string {
+	if cfg.Env != "" {
+		return cfg.Env
+	}
+	return gitBranch()
+}
+
+// selectBaselinePath picks the results baseline for this run: the one
+// keyed by env/branch when it exists, falling back to the default
+// branch's baseline for new feature branches, then to the plain
+// promoted baseline file. "" means no baseline of any kind.
+func selectBaselinePath(cfg *config.Config, st *store.Store) string {
+	if key := baselineKey(cfg); key != "" {
+		if p := filepath.Join(st.BaselinesDir(), key+".json"); fileExists(p) {
+			return p
+		}
+	}
+	for _, def := range []string{"main", "master"} {
+		if p := filepath.Join(st.BaselinesDir(), def+".json"); fileExists(p) {
+			return p
+		}
+	}
+	if fileExists(st.ResultsBaselinePath()) {
+		return st.ResultsBaselinePath()
+	}
+	return ""
+}
+
+// costGate enforces the configured spend budgets: an absolute per-run
+// ceiling and a percentage increase over the baseline run. When one
+// trips, the most expensive calls are listed so the offender is
+// obvious.
+func costGate(cfg *config.Config, sum *runSummary) error {
+	reason := costGateReason(cfg, sum)
+	if reason == "" {
+		return nil
+	}
+	for _, line := range sum.TopCosts {
+		fmt.Println("  " + line)
+	}
+	return exitWithCode(fmt.Errorf("%s", reason), 1)
+}
+
+// costGateReason describes which cost budget the run blew, "" when
+// none did.
+func costGateReason(cfg *config.Config, sum *runSummary) string {
+	if m := cfg.Gate.MaxCostUSD; m > 0 && sum.CostUSD > m {
+		return fmt.Sprintf("estimated run cost $%.4f exceeds budget $%.4f", sum.CostUSD, m)
+	}
+	if p := cfg.Gate.MaxCostIncreasePct; p > 0 && sum.BaselineCostUSD > 0 {
+		inc := (sum.CostUSD - sum.BaselineCostUSD) / sum.BaselineCostUSD * 100
+		if inc > p {
+			return fmt.Sprintf("estimated run cost $%.4f is %.0f%% above baseline $%.4f (allowed %.0f%%)",
+				sum.CostUSD, inc, sum.BaselineCostUSD, p)
+		}
+	}
+	return ""
+}
+
+// gateFailed reports whether the exit gates would fail the run. It is
+// computed up front so the published commit status carries the final
+// verdict even when the process exit code is swallowed downstream.
+func gateFailed(cfg *config.Config, opts runOptions, sum *runSummary) bool {
+	switch {
+	case opts.RequireBaseline && sum.BaselineStatus != evals.BaselineOK,
+		len(sum.Regressions) > 0,
+		sum.failed(),
+		cfg.Gate.Threshold > 0 && sum.Score < cfg.Gate.Threshold,
+		costGateReason(cfg, sum) != "",
+		cfg.Gate.FailOnInfra && sum.Infra > 0:
+		return true
+	}
+	return false
+}
+
+// topCallCosts lists the n most expensive calls of a session, highest
+// first, skipping calls with no estimated cost.
+func topCallCosts(s *trace.Session, n int) []string {
+	calls := make([]trace.Call, len(s.Calls))
+	copy(calls, s.Calls)
+	sort.Slice(calls, func(i, j int) bool { return calls[i].CostUSD > calls[j].CostUSD })
+	var out []string
+	for _, c := range calls {
+		if c.CostUSD <= 0 || len(out) == n {
+			break
+		}
+		out = append(out, fmt.Sprintf("call #%d %s: $%.4f", c.Index, c.Model, c.CostUSD))
+	}
+	return out
+}
+
+// runProject executes the suite for one project, persists results and
+// history, and annotates regressions against the baseline.
+func runProject(cfg *config.Config, opts runOptions) (*runSummary, error) {
+	opts, err := applyProfile(cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range cfg.Evals.Plugins {
+		name := strings.TrimSuffix(filepath.Base(p), ".wasm")
+		if err := evals.RegisterWASMPlugin(name, filepath.Join(cfg.Root, p)); err != nil {
+			return nil, err
+		}
+	}
+	suite, err := evals.LoadSuite(cfg.SuitePath())
+	if err != nil {
+		return nil, err
+	}
+	st := store.Open(cfg.StateDir())
+	runner := evals.NewRunner(suite)
+	if opts.Strict {
+		runner.Strict = true
+	}
+	runner.ReRecord = opts.ReRecord
+	runner.Concurrent = cfg.Evals.Concurrent
+	runner.Filter = opts.Filter
+	runner.Tags = opts.Tags
+	runner.DetectFlaky = opts.DetectFlaky
+	runner.Repeat = opts.Repeat
+	// The latest recorded session, when one exists, lets trace-aware
+	// checks inspect the real provider traffic behind each test.
+	if path, err := store.LatestFile(st.SessionsDir(), "*.json"); err == nil {
+		if s, err := trace.Load(path); err == nil {
+			runner.Trace = s
+		}
+	}
+	hk := hooks.New(cfg.Hooks, cfg.Root)
+	hk.Fire(hooks.EventRunStarted, map[string]any{"suite": suite.Name, "tests": len(suite.Tests)})
+	res, err := runner.Run(nil)
+	if err != nil {
+		return nil, err
+	}
+	applyPricing(cfg)
+	if runner.Trace != nil {
+		res.CostUSD = gate.StampCosts(runner.Trace)
+	}
+	for _, tr := range res.Tests {
+		hk.Fire(hooks.EventTestFinished, map[string]any{"run_id": res.RunID, "name": tr.Name, "status": tr.Status, "score": tr.Score})
+	}
+
+	pullBaselines(cfg, st)
+	b, err := baseline.Load(st.BaselinePath())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	// A promoted results baseline takes precedence over the status
+	// baseline: it carries full per-test results of a known-good run.
+	// --compare-to overrides both with a run pulled from history.
+	var resBase *evals.Results
+	if opts.CompareTo != "" {
+		resBase, err = resolveCompareRun(st, opts.CompareTo)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("  comparing against run %s (%s)\n", resBase.RunID, resBase.StartedAt.Format("2006-01-02 15:04"))
+	} else if path := selectBaselinePath(cfg, st); path != "" {
+		resBase, err = evals.LoadResults(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	res.BaselineStatus = baselineStatus(res, resBase, b)
+
+	if err := st.EnsureDir(st.HistoryDir()); err != nil {
+		return nil, err
+	}
+	if err := res.Save(st.ResultsPath()); err != nil {
+		return nil, err
+	}
+	if err := res.Save(filepath.Join(st.HistoryDir(), res.RunID+".json")); err != nil {
+		return nil, err
+	}
+	if ret := (store.Retention{Runs: cfg.Retain.Runs, RollupAfterDays: cfg.Retain.RollupAfterDays}); ret.Enabled() {
+		// Retention failures never fail the run; the records are
+		// advisory and the next run retries.
+		if _, err := st.Prune(ret, time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, "regrada: pruning history: %v\n", err)
+		}
+	}
+	if len(cfg.Notify.Webhooks) > 0 {
+		// Deliveries are best-effort, like hooks: a down receiver is
+		// reported but never fails the run.
+		if data, err := json.Marshal(res); err == nil {
+			for _, url := range cfg.Notify.Webhooks {
+				if err := webhook.Deliver(url, data, cfg.Notify.WebhookSecret); err != nil {
+					fmt.Fprintf(os.Stderr, "regrada: webhook %s: %v\n", url, err)
+				}
+			}
+		}
+	}
+	if opts.Output == "junit" || opts.Output == "gitlab" {
+		if err := st.EnsureDir(st.ReportsDir()); err != nil {
+			return nil, err
+		}
+		path := filepath.Join(st.ReportsDir(), "junit.xml")
+		if err := writeJUnit(res, path); err != nil {
+			return nil, err
+		}
+		fmt.Printf("  junit report: %s\n", path)
+	}
+	if opts.Output == "gitlab" {
+		suitePath := cfg.SuitePath()
+		if rel, err := filepath.Rel(cfg.Root, suitePath); err == nil {
+			suitePath = rel
+		}
+		path := filepath.Join(st.ReportsDir(), "gl-code-quality.json")
+		if err := writeCodeQuality(res, suitePath, path); err != nil {
+			return nil, err
+		}
+		fmt.Printf("  code quality report: %s\n", path)
+	}
+	if opts.Artifacts {
+		runDir, err := res.WriteArtifacts(st.ArtifactsDir())
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("  artifacts: %s\n", runDir)
+	}
+
+	q, err := evals.LoadQuarantine(st.QuarantinePath())
+	if err != nil {
+		return nil, err
+	}
+	sum := &runSummary{Score: res.Score, BudgetExceeded: res.BudgetExceeded, BaselineStatus: res.BaselineStatus, CostUSD: res.CostUSD}
+	if resBase != nil {
+		sum.BaselineCostUSD = resBase.CostUSD
+	}
+	if runner.Trace != nil {
+		sum.TopCosts = topCallCosts(runner.Trace, 5)
+	}
+	for _, tr := range res.Tests {
+		quarantined := q.Has(tr.Name)
+		marker := "PASS"
+		switch tr.Status {
+		case evals.StatusPass:
+			sum.Passed++
+			if tr.Flaky {
+				// Passed, but only after retries: flag it so the suite's
+				// unreliable tests stay visible.
+				marker = "FLAKY"
+				sum.Flaky++
+			}
+		case evals.StatusInfraError:
+			// Infrastructure errors are not behavioral failures: count
+			// them apart so they never show up as regressions.
+			marker = "INFR"
+			sum.Infra++
+		case evals.StatusSkipped:
+			marker = "SKIP"
+			sum.Skipped++
+		case evals.StatusXFail:
+			marker = "XFAIL"
+			sum.XFailed++
+		case evals.StatusXPass:
+			// An unexpected pass still passes; the marker flags that the
+			// xfail annotation is stale.
+			marker = "XPASS"
+			sum.Passed++
+		default:
+			if quarantined {
+				// Quarantined tests were pre-triaged as flaky during
+				// review: their failures stay visible but neither gate
+				// nor count as regressions.
+				marker = "QUAR"
+				sum.Flaky++
+				if sum.ByCategory == nil {
+					sum.ByCategory = map[string][]string{}
+				}
+				sum.ByCategory[evals.CategoryFlaky] = append(sum.ByCategory[evals.CategoryFlaky], tr.Name)
+				break
+			}
+			marker = "FAIL"
+			if tr.Status == evals.StatusError {
+				marker = "ERR"
+			}
+			sum.Failed++
+			if tr.Flaky {
+				// The failure did not reproduce on reruns: triage it as
+				// flakiness, not as a behavioral failure.
+				marker = "FLAKY"
+				sum.Flaky++
+				if sum.ByCategory == nil {
+					sum.ByCategory = map[string][]string{}
+				}
+				sum.ByCategory[evals.CategoryFlaky] = append(sum.ByCategory[evals.CategoryFlaky], tr.Name)
+			} else {
+				sum.addCategories(tr)
+			}
+		}
+		for _, c := range tr.Checks {
+			if c.Unknown && !contains(sum.Unimplemented, c.Name) {
+				sum.Unimplemented = append(sum.Unimplemented, c.Name)
+			}
+		}
+		suffix := ""
+		if tr.Reason != "" {
+			suffix = " (" + tr.Reason + ")"
+		}
+		fmt.Printf("  %-5s %s%s\n", marker, tr.Name, suffix)
+		baseStatus := ""
+		if resBase != nil {
+			if bt := resBase.Test(tr.Name); bt != nil {
+				baseStatus = bt.Status
+			}
+		} else if b != nil {
+			if bt, ok := b.Tests[tr.Name]; ok {
+				baseStatus = bt.Status
+			}
+		}
+		if baseStatus == evals.StatusPass && tr.Status == evals.StatusFail && !tr.Flaky && !quarantined {
+			sum.Regressions = append(sum.Regressions, tr.Name)
+		}
+	}
+	if len(sum.Regressions) > 0 {
+		hk.Fire(hooks.EventRegressionDetected, map[string]any{"run_id": res.RunID, "tests": sum.Regressions})
+	}
+	if opts.Summarize {
+		var changes []judge.Change
+		if resBase != nil {
+			for _, tr := range res.Tests {
+				if bt := resBase.Test(tr.Name); bt != nil && bt.Status != tr.Status {
+					changes = append(changes, judge.Change{Test: tr.Name, Before: bt.Response, After: tr.Response})
+				}
+			}
+		}
+		if len(changes) == 0 {
+			fmt.Println("  no status changes to summarize")
+		} else if summary, err := judge.Summarize(cfg.Judge.Model, changes); err != nil {
+			// A down judge loses the paragraph, not the run.
+			fmt.Fprintf(os.Stderr, "warning: summarize: %v\n", err)
+		} else {
+			res.ChangeSummary = summary
+			// Re-save so the PR comment and HTML report pick it up.
+			if err := res.Save(st.ResultsPath()); err != nil {
+				return nil, err
+			}
+			if err := res.Save(filepath.Join(st.HistoryDir(), res.RunID+".json")); err != nil {
+				return nil, err
+			}
+			fmt.Printf("\nChange summary:\n  %s\n", summary)
+		}
+	}
+	return sum, nil
+}
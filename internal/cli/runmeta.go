@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// newRunSeed returns a fresh random seed for one traced run. Exposing
+// it lets applications that honor REGRADA_SEED make their own
+// randomness reproducible per run.
+func newRunSeed() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// gitSHA returns the commit under test: the CI-provided SHA when
+// available, otherwise the local HEAD, or "" outside a repository.
+func gitSHA() string {
+	for _, name := range []string{"GITHUB_SHA", "CI_COMMIT_SHA"} {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	out, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// gitBranch returns the branch under test: the CI-provided ref when
+// available, otherwise the local checkout's branch, or "" outside a
+// repository.
+func gitBranch() string {
+	for _, name := range []string{"GITHUB_REF_NAME", "CI_COMMIT_REF_NAME"} {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	out, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// runMetadataEnv returns the env vars that expose run-level metadata
+// to a traced command, so its own logs can be correlated back to this
+// regrada run.
+func runMetadataEnv(runID, seed, sha string) []string {
+	env := []string{
+		"REGRADA_RUN_ID=" + runID,
+		"REGRADA_SEED=" + seed,
+	}
+	if sha != "" {
+		env = append(env, "REGRADA_GIT_SHA="+sha)
+	}
+	return env
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/config"
+	"github.com/regrada-ai/regrada/internal/server"
+	"github.com/regrada-ai/regrada/internal/store"
+)
+
+var serveCmd = &Command{
+	Name:    "serve",
+	Summary: "Serve a shared baseline and results API over HTTP",
+	Usage:   "[--addr :8090] [--dir path] [--token token]",
+}
+
+func init() {
+	serveCmd.Run = runServe
+	register(serveCmd)
+}
+
+// runServe starts the team server: one store per project under --dir,
+// exposed through the HTTP API in internal/server. CI jobs publish
+// into it with `regrada run --server <url>`.
+func runServe(ctx *Context, args []string) error {
+	fs := newFlagSet(serveCmd)
+	addr := fs.String("addr", ":8090", "listen address")
+	dir := fs.String("dir", "", "directory holding per-project state (default: .regrada/server)")
+	token := fs.String("token", os.Getenv("REGRADA_SERVER_TOKEN"), "bearer token clients must present (default: $REGRADA_SERVER_TOKEN; empty disables auth)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError(serveCmd, "unexpected argument %q", fs.Arg(0))
+	}
+	if *dir == "" {
+		st, err := ctx.Store()
+		if err != nil {
+			return err
+		}
+		*dir = filepath.Join(st.Root(), "server")
+	}
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		return err
+	}
+	if *token == "" {
+		fmt.Fprintln(os.Stderr, "regrada: serving without auth; set --token or REGRADA_SERVER_TOKEN")
+	}
+	fmt.Printf("Serving %s on %s\n", *dir, *addr)
+	return http.ListenAndServe(*addr, server.New(*dir, *token).Handler())
+}
+
+// pushToServer publishes the latest results and recorded session to a
+// regrada server so other repos and CI jobs read one source of truth.
+// Auth comes from REGRADA_SERVER_TOKEN; like webhooks, a down server
+// warns but never fails the run.
+func pushToServer(cfg *config.Config, serverURL string) {
+	project := cfg.Project
+	if project == "" {
+		project = "default"
+	}
+	base := strings.TrimSuffix(serverURL, "/") + server.Prefix + project
+	st := store.Open(cfg.StateDir())
+
+	if data, err := os.ReadFile(st.ResultsPath()); err == nil {
+		postToServer(base+"/results", data)
+	}
+	if path, err := store.LatestFile(st.SessionsDir(), "*.json"); err == nil && path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			postToServer(base+"/sessions", data)
+		}
+	}
+}
+
+func postToServer(url string, body []byte) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "regrada: server push: %v\n", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token := os.Getenv("REGRADA_SERVER_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "regrada: server push: %v\n", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		fmt.Fprintf(os.Stderr, "regrada: server push: %s returned %s\n", url, resp.Status)
+		return
+	}
+	fmt.Printf("  pushed to %s\n", url)
+}
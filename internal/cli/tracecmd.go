@@ -0,0 +1,249 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/gate"
+	"github.com/regrada-ai/regrada/internal/otel"
+	"github.com/regrada-ai/regrada/internal/proxy"
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+var traceCmd = &Command{
+	Name:    "trace",
+	Summary: "Run a command with its provider traffic recorded",
+	Usage:   "[flags] -- <command> [args...]",
+}
+
+func init() {
+	traceCmd.Run = runTrace
+	register(traceCmd)
+}
+
+func runTrace(ctx *Context, args []string) error {
+	fs := newFlagSet(traceCmd)
+	envFlag := fs.String("env", "", "environment tag for the recorded session")
+	serviceFlag := fs.String("service", os.Getenv("REGRADA_SERVICE"), "service name attributed to recorded calls")
+	chaosRate := fs.Float64("chaos", 0, "fraction of calls to fault-inject (0..1)")
+	chaosFaults := fs.String("chaos-faults", "latency,429,500", "comma-separated faults to inject")
+	chaosLatency := fs.Duration("chaos-latency", 2*time.Second, "delay added by the latency fault")
+	chaosSeed := fs.Int64("chaos-seed", 0, "seed for reproducible fault placement")
+	mitm := fs.Bool("mitm", false, "intercept HTTPS CONNECT tunnels with the local CA")
+	installCA := fs.Bool("install-ca", false, "generate the local root CA and print trust instructions")
+	dryRun := fs.Bool("dry-run", false, "print how the command would be traced without running it")
+	replayFlag := fs.String("replay", "", "serve responses from a recorded session (path or id) instead of calling upstream")
+	preflight := fs.Bool("preflight", false, "ping configured providers before tracing and fail fast on auth errors")
+	noLock := fs.Bool("no-lock", false, "skip the project run lock (risks corrupting state)")
+	otelEndpoint := fs.String("otel-endpoint", "", "OTLP/HTTP collector base URL to export the session's spans to (overrides otel.endpoint)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *installCA {
+		return installLocalCA()
+	}
+	var chaos *proxy.Chaos
+	if *chaosRate > 0 {
+		faults, err := proxy.ParseChaosFaults(*chaosFaults)
+		if err != nil {
+			return err
+		}
+		chaos = &proxy.Chaos{Rate: *chaosRate, Faults: faults, Latency: *chaosLatency, Seed: *chaosSeed}
+	}
+	cmdArgs := fs.Args()
+	if len(cmdArgs) > 0 && cmdArgs[0] == "--" {
+		cmdArgs = cmdArgs[1:]
+	}
+	if len(cmdArgs) == 0 {
+		return usageError(traceCmd, "command to trace expected after --")
+	}
+
+	cfg, err := ctx.Config()
+	if err != nil {
+		return err
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	if *envFlag == "" {
+		*envFlag = cfg.Env
+	}
+
+	if *dryRun {
+		fmt.Printf("Would trace: %s\n", strings.Join(cmdArgs, " "))
+		if *serviceFlag != "" {
+			fmt.Printf("  service: %s\n", *serviceFlag)
+		}
+		if *envFlag != "" {
+			fmt.Printf("  env tag: %s\n", *envFlag)
+		}
+		if len(cfg.Rewrite) > 0 {
+			fmt.Printf("  rewrite rules: %d\n", len(cfg.Rewrite))
+		}
+		if chaos != nil {
+			fmt.Printf("  chaos: %.0f%% of calls, faults %s\n", chaos.Rate*100, strings.Join(chaos.Faults, ","))
+		}
+		fmt.Println("  environment overrides:")
+		env := proxy.BaseURLEnv("http://127.0.0.1:<port>")
+		if *mitm {
+			env = append(env, proxy.MITMEnv("http://127.0.0.1:<port>", "<ca-cert>")...)
+		}
+		for _, kv := range env {
+			fmt.Printf("    %s\n", kv)
+		}
+		fmt.Printf("  session saved to: %s\n", st.SessionsDir())
+		fmt.Println("Dry run: nothing was executed.")
+		return nil
+	}
+
+	if *preflight {
+		if err := preflightProviders(); err != nil {
+			return err
+		}
+	}
+
+	release, err := acquireRunLock(ctx, *noLock)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	opts := proxy.Options{Env: *envFlag, Rewrites: cfg.Rewrite, Headers: cfg.ProviderHeaders(), Endpoints: cfg.ProviderEndpoints(), Chaos: chaos, Service: *serviceFlag, InjectMetadata: true}
+	if *replayFlag != "" {
+		var recorded *trace.Session
+		if fileExists(*replayFlag) {
+			recorded, err = trace.Load(*replayFlag)
+		} else {
+			recorded, err = trace.Find(st.SessionsDir(), *replayFlag)
+		}
+		if err != nil {
+			return err
+		}
+		opts.Replay = proxy.NewReplayer(recorded)
+		fmt.Printf("Replaying %d recorded calls from session %s; nothing will reach the providers.\n",
+			len(recorded.Calls), recorded.ID)
+	}
+	if *mitm {
+		caDir, err := proxy.DefaultCADir()
+		if err != nil {
+			return err
+		}
+		opts.CA, err = proxy.LoadOrCreateCA(caDir)
+		if err != nil {
+			return fmt.Errorf("loading local CA: %w", err)
+		}
+	}
+	p := proxy.New(opts)
+	base, err := p.Start()
+	if err != nil {
+		return fmt.Errorf("starting proxy: %w", err)
+	}
+
+	cmd := exec.Command(cmdArgs[0], cmdArgs[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), proxy.BaseURLEnv(base)...)
+	seed, sha := newRunSeed(), gitSHA()
+	cmd.Env = append(cmd.Env, runMetadataEnv(p.RunID(), seed, sha)...)
+	if *mitm {
+		cmd.Env = append(cmd.Env, proxy.MITMEnv(base, opts.CA.CertPath)...)
+	}
+	runErr := cmd.Run()
+
+	session := p.Close()
+	if session.Labels == nil {
+		session.Labels = map[string]string{}
+	}
+	session.Labels["seed"] = seed
+	if sha != "" {
+		session.Labels["git_sha"] = sha
+	}
+	applyPricing(cfg)
+	cost := gate.StampCosts(session)
+	path, err := session.Save(st.SessionsDir())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Recorded %d calls to %s\n", len(session.Calls), path)
+	if *otelEndpoint == "" {
+		*otelEndpoint = cfg.OTel.Endpoint
+	}
+	exportOTel(*otelEndpoint, session)
+	prof := trace.BuildProfile([]*trace.Session{session})
+	if len(prof.Services) > 0 {
+		for _, name := range trace.SortedKeys(prof.Services) {
+			fmt.Printf("  %s: %d calls\n", name, prof.Services[name])
+		}
+	}
+	for _, ep := range trace.SortedEndpoints(prof.Endpoints) {
+		es := prof.Endpoints[ep]
+		fmt.Printf("  %s: %d calls, %.0fms mean, %d tokens\n", ep, es.Calls, es.MeanLatencyMS, es.Tokens)
+	}
+	if prof.EmbeddingCalls > 0 {
+		fmt.Printf("  embeddings: %d calls, %d tokens\n", prof.EmbeddingCalls, prof.EmbeddingTokens)
+	}
+	if cost > 0 {
+		fmt.Printf("  estimated cost: $%.4f\n", cost)
+	}
+	if minReq, minTok := session.RateLimitLow(); minReq >= 0 || minTok >= 0 {
+		fmt.Print("Rate limit headroom (lowest observed):")
+		if minReq >= 0 {
+			fmt.Printf(" %d requests", minReq)
+		}
+		if minTok >= 0 {
+			fmt.Printf(" %d tokens", minTok)
+		}
+		fmt.Println()
+	}
+	if mean, n := session.ProxyOverheadMS(); n > 0 && mean > overheadWarnMS {
+		fmt.Fprintf(os.Stderr, "warning: mean proxy overhead is %.0fms across %d calls (threshold %dms); latency numbers in this session are partly regrada-induced\n",
+			mean, n, overheadWarnMS)
+	}
+	if runErr != nil {
+		return fmt.Errorf("traced command: %w", runErr)
+	}
+	return nil
+}
+
+// overheadWarnMS is the mean proxy overhead, in milliseconds, above
+// which a trace run warns that it is skewing latency measurements.
+const overheadWarnMS = 100
+
+// exportOTel ships the session to the OTLP collector when one is
+// configured. Export failures warn instead of erroring: a down
+// collector must not invalidate the recording.
+func exportOTel(endpoint string, session *trace.Session) {
+	if endpoint == "" {
+		return
+	}
+	n, err := otel.Export(endpoint, session)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: otel export: %v\n", err)
+		return
+	}
+	fmt.Printf("Exported %d spans to %s\n", n, endpoint)
+}
+
+// installLocalCA generates (or loads) the local root CA and tells the
+// user how to trust it.
+func installLocalCA() error {
+	caDir, err := proxy.DefaultCADir()
+	if err != nil {
+		return err
+	}
+	ca, err := proxy.LoadOrCreateCA(caDir)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Local root CA ready at %s\n\n", ca.CertPath)
+	fmt.Println("Traced commands trust it automatically via SSL_CERT_FILE and")
+	fmt.Println("NODE_EXTRA_CA_CERTS. To trust it system-wide:")
+	fmt.Printf("  macOS:  security add-trusted-cert -k ~/Library/Keychains/login.keychain %s\n", ca.CertPath)
+	fmt.Printf("  Linux:  sudo cp %s /usr/local/share/ca-certificates/regrada-ca.crt && sudo update-ca-certificates\n", ca.CertPath)
+	return nil
+}
@@ -0,0 +1,385 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/store"
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+var tracesCmd = &Command{
+	Name:    "traces",
+	Summary: "Inspect and manipulate recorded trace sessions",
+	Usage:   "list | show <session-id> | search [--provider name] [--model name] [--since date] <text> | merge <session.json>... -o <out.json> | export [session-id] -o <out.json> | stats [session-id]",
+}
+
+func init() {
+	tracesCmd.Run = runTraces
+	register(tracesCmd)
+}
+
+func runTraces(ctx *Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(tracesCmd, "subcommand expected")
+	}
+	switch args[0] {
+	case "list":
+		return runTracesList(ctx, args[1:])
+	case "show":
+		return runTracesShow(ctx, args[1:])
+	case "search":
+		return runTracesSearch(ctx, args[1:])
+	case "merge":
+		return runTracesMerge(ctx, args[1:])
+	case "export":
+		return runTracesExport(ctx, args[1:])
+	case "stats":
+		return runTracesStats(ctx, args[1:])
+	default:
+		return usageError(tracesCmd, "unknown subcommand %q", args[0])
+	}
+}
+
+// runTracesList prints one line per recorded session, newest first,
+// so the right id for show/export/stats is findable without opening
+// raw JSON files.
+func runTracesList(ctx *Context, args []string) error {
+	fs := newFlagSet(tracesCmd)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError(tracesCmd, "unexpected argument %q", fs.Arg(0))
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	sessions, err := trace.List(st.SessionsDir())
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		return fmt.Errorf("no sessions in %s", st.SessionsDir())
+	}
+	for _, s := range sessions {
+		prof := trace.BuildProfile([]*trace.Session{s})
+		env := ""
+		if s.Env != "" {
+			env = "  env=" + s.Env
+		}
+		fmt.Printf("%s  %s  %3d calls  %5.0fms mean%s\n",
+			s.ID, s.StartedAt.Format("2006-01-02 15:04:05"), prof.Calls, prof.MeanLatencyMS, env)
+	}
+	return nil
+}
+
+// runTracesShow prints every call of one session: model, status,
+// latency, token usage and tool calls, one line each.
+func runTracesShow(ctx *Context, args []string) error {
+	fs := newFlagSet(tracesCmd)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError(tracesCmd, "exactly one session id expected")
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	session, err := trace.Find(st.SessionsDir(), fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Session %s, started %s, %d calls\n",
+		session.ID, session.StartedAt.Format("2006-01-02 15:04:05"), len(session.Calls))
+	if session.Env != "" {
+		fmt.Printf("  env: %s\n", session.Env)
+	}
+	labels := make([]string, 0, len(session.Labels))
+	for k := range session.Labels {
+		labels = append(labels, k)
+	}
+	sort.Strings(labels)
+	for _, k := range labels {
+		fmt.Printf("  %s: %s\n", k, session.Labels[k])
+	}
+	for _, c := range session.Calls {
+		name := c.Model
+		if name == "" {
+			name = trace.EndpointPath(c.URL)
+		}
+		fmt.Printf("  #%-3d %-30s %d  %5.0fms  %d+%d tokens", c.Index, name, c.Status, c.DurationMS, c.PromptTokens, c.CompletionTokens)
+		if c.CostUSD > 0 {
+			fmt.Printf("  $%.4f", c.CostUSD)
+		}
+		if len(c.ToolCalls) > 0 {
+			fmt.Printf("  tools: %s", strings.Join(c.ToolCalls, ", "))
+		}
+		if c.Fault != "" {
+			fmt.Printf("  fault: %s", c.Fault)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// runTracesSearch greps request and response bodies across every
+// stored session, so the call that produced a specific answer can be
+// tracked down without opening raw JSON files.
+func runTracesSearch(ctx *Context, args []string) error {
+	fs := newFlagSet(tracesCmd)
+	provider := fs.String("provider", "", "only search calls to this provider")
+	model := fs.String("model", "", "only search calls to this model")
+	since := fs.String("since", "", "only search sessions started on or after this date (2006-01-02)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError(tracesCmd, "exactly one search text expected")
+	}
+	query := fs.Arg(0)
+	var cutoff time.Time
+	if *since != "" {
+		t, err := time.Parse("2006-01-02", *since)
+		if err != nil {
+			return usageError(tracesCmd, "invalid --since date %q, want 2006-01-02", *since)
+		}
+		cutoff = t
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+	sessions, err := trace.List(st.SessionsDir())
+	if err != nil {
+		return err
+	}
+
+	hits := 0
+	for _, s := range sessions {
+		if !cutoff.IsZero() && s.StartedAt.Before(cutoff) {
+			continue
+		}
+		for i := range s.Calls {
+			c := &s.Calls[i]
+			if *provider != "" && c.Provider != *provider {
+				continue
+			}
+			if *model != "" && c.Model != *model {
+				continue
+			}
+			name := c.Model
+			if name == "" {
+				name = trace.EndpointPath(c.URL)
+			}
+			for _, side := range []struct {
+				label string
+				body  []byte
+			}{{"request", c.Request}, {"response", c.Response}} {
+				snippet, ok := matchSnippet(side.body, query)
+				if !ok {
+					continue
+				}
+				fmt.Printf("%s call #%d %s (%s): %s\n", s.ID, c.Index, name, side.label, snippet)
+				hits++
+			}
+		}
+	}
+	if hits == 0 {
+		return fmt.Errorf("no calls match %q", query)
+	}
+	fmt.Printf("%d matches\n", hits)
+	return nil
+}
+
+// matchSnippet reports whether body contains text, ignoring case, and
+// returns the first match with surrounding context, whitespace
+// collapsed to keep the hit on one line.
+func matchSnippet(body []byte, text string) (string, bool) {
+	lower := strings.ToLower(string(body))
+	idx := strings.Index(lower, strings.ToLower(text))
+	if idx < 0 {
+		return "", false
+	}
+	const context = 40
+	start := idx - context
+	if start < 0 {
+		start = 0
+	}
+	end := idx + len(text) + context
+	if end > len(body) {
+		end = len(body)
+	}
+	snippet := strings.Join(strings.Fields(string(body[start:end])), " ")
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(body) {
+		snippet += "..."
+	}
+	return snippet, true
+}
+
+// runTracesExport renders a session in Chrome trace-event format for
+// flame-graph tools (Perfetto, chrome://tracing). Without a session id
+// the most recent session is exported.
+func runTracesExport(ctx *Context, args []string) error {
+	fs := newFlagSet(tracesCmd)
+	out := fs.String("o", "", "output file for the Chrome trace")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return usageError(tracesCmd, "at most one session id expected")
+	}
+	if *out == "" {
+		return usageError(tracesCmd, "-o is required")
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+
+	var session *trace.Session
+	if fs.NArg() == 1 {
+		session, err = trace.Find(st.SessionsDir(), fs.Arg(0))
+	} else {
+		var path string
+		path, err = store.LatestFile(st.SessionsDir(), "*.json")
+		if err == nil && path == "" {
+			err = fmt.Errorf("no sessions in %s", st.SessionsDir())
+		}
+		if err == nil {
+			session, err = trace.Load(path)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := trace.ChromeTrace(session)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*out, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("Exported session %s (%d calls) to %s\n", session.ID, len(session.Calls), *out)
+	return nil
+}
+
+// runTracesStats prints the aggregated profile of one session, or of
+// every recorded session when no id is given: models, endpoints,
+// tools and latency, so the call mix is visible at a glance.
+func runTracesStats(ctx *Context, args []string) error {
+	fs := newFlagSet(tracesCmd)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return usageError(tracesCmd, "at most one session id expected")
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return err
+	}
+
+	var sessions []*trace.Session
+	if fs.NArg() == 1 {
+		s, err := trace.Find(st.SessionsDir(), fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		sessions = []*trace.Session{s}
+	} else {
+		sessions, err = trace.List(st.SessionsDir())
+		if err != nil {
+			return err
+		}
+		if len(sessions) == 0 {
+			return fmt.Errorf("no sessions in %s", st.SessionsDir())
+		}
+	}
+
+	prof := trace.BuildProfile(sessions)
+	fmt.Printf("%d sessions, %d calls, mean latency %.0fms\n", prof.Sessions, prof.Calls, prof.MeanLatencyMS)
+	if prof.Calls > 0 {
+		fmt.Printf("Latency percentiles: p50 %.0fms, p95 %.0fms, p99 %.0fms\n", prof.P50LatencyMS, prof.P95LatencyMS, prof.P99LatencyMS)
+	}
+	if len(prof.Models) > 0 {
+		fmt.Println("Models:")
+		for _, name := range trace.SortedKeys(prof.Models) {
+			fmt.Printf("  %-40s %d\n", name, prof.Models[name])
+		}
+	}
+	if len(prof.Endpoints) > 0 {
+		fmt.Println("Endpoints:")
+		for _, ep := range trace.SortedEndpoints(prof.Endpoints) {
+			es := prof.Endpoints[ep]
+			fmt.Printf("  %-40s %d calls, %.0fms mean, %d tokens\n", ep, es.Calls, es.MeanLatencyMS, es.Tokens)
+		}
+	}
+	if len(prof.Tools) > 0 {
+		fmt.Println("Tool calls:")
+		for _, name := range trace.SortedKeys(prof.Tools) {
+			fmt.Printf("  %-40s %d\n", name, prof.Tools[name])
+		}
+	}
+	if prof.EmbeddingCalls > 0 {
+		fmt.Printf("Embeddings: %d calls, %d tokens\n", prof.EmbeddingCalls, prof.EmbeddingTokens)
+	}
+	if prof.CostUSD > 0 {
+		fmt.Printf("Estimated cost: $%.4f\n", prof.CostUSD)
+	}
+	return nil
+}
+
+func runTracesMerge(ctx *Context, args []string) error {
+	fs := newFlagSet(tracesCmd)
+	out := fs.String("o", "", "output file for the merged session")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 {
+		return usageError(tracesCmd, "at least two session files expected")
+	}
+	if *out == "" {
+		return usageError(tracesCmd, "-o is required")
+	}
+
+	var sessions []*trace.Session
+	for _, path := range fs.Args() {
+		s, err := trace.Load(path)
+		if err != nil {
+			return err
+		}
+		sessions = append(sessions, s)
+	}
+	merged, err := trace.Merge(sessions)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*out, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	total := 0
+	for _, s := range sessions {
+		total += len(s.Calls)
+	}
+	fmt.Printf("Merged %d sessions (%d calls, %d after de-duplication) into %s\n",
+		len(sessions), total, len(merged.Calls), *out)
+	return nil
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/regrada-ai/regrada/internal/selfupdate"
+	"github.com/regrada-ai/regrada/internal/version"
+)
+
+var updateCmd = &Command{
+	Name:    "update",
+	Summary: "Update regrada to the latest release",
+	Usage:   "[--check]",
+}
+
+func init() {
+	updateCmd.Run = runUpdate
+	register(updateCmd)
+}
+
+func runUpdate(ctx *Context, args []string) error {
+	fs := newFlagSet(updateCmd)
+	checkOnly := fs.Bool("check", false, "only report whether an update is available")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	rel, err := selfupdate.Check()
+	if err != nil {
+		return err
+	}
+	if rel.Version == version.Version {
+		fmt.Printf("regrada %s is up to date\n", version.Version)
+		return nil
+	}
+	if *checkOnly {
+		fmt.Printf("update available: %s -> %s (run `regrada update` to install)\n", version.Version, rel.Version)
+		return nil
+	}
+	fmt.Printf("updating %s -> %s\n", version.Version, rel.Version)
+	if err := rel.Apply(); err != nil {
+		return err
+	}
+	fmt.Printf("installed regrada %s\n", rel.Version)
+	return nil
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"os"
+	"strings"
+)
+
+// fileExists reports whether path exists and is a regular file.
+func fileExists(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.Mode().IsRegular()
+}
+
+// acquireRunLock takes the project run lock unless noLock disables it,
+// returning a release func that is always safe to call.
+func acquireRunLock(ctx *Context, noLock bool) (func(), error) {
+	if noLock {
+		return func() {}, nil
+	}
+	st, err := ctx.Store()
+	if err != nil {
+		return nil, err
+	}
+	lock, err := st.AcquireLock()
+	if err != nil {
+		return nil, err
+	}
+	return lock.Release, nil
+}
+
+// splitList splits a comma-separated flag value, dropping empties.
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// contains reports whether list holds s.
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
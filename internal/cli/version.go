@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/regrada-ai/regrada/internal/version"
+)
+
+var versionCmd = &Command{
+	Name:    "version",
+	Summary: "Print version and build metadata",
+	Usage:   "[--json]",
+}
+
+func init() {
+	versionCmd.Run = runVersion
+	register(versionCmd)
+}
+
+func runVersion(ctx *Context, args []string) error {
+	fs := newFlagSet(versionCmd)
+	asJSON := fs.Bool("json", false, "print machine-readable JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]string{
+			"version": version.Version,
+			"commit":  version.Commit,
+			"date":    version.Date,
+			"go":      runtime.Version(),
+			"os":      runtime.GOOS,
+			"arch":    runtime.GOARCH,
+		})
+	}
+	fmt.Println(version.String())
+	return nil
+}
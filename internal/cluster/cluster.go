@@ -0,0 +1,148 @@
+// Package cluster groups recorded model responses into behavioral
+// clusters so a new failure mode or style shift stands out as a
+// cluster that earlier runs never produced.
+package cluster
+
+import (
+	"math"
+	"sort"
+
+	"github.com/regrada-ai/regrada/internal/providers"
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+// Member is one response assigned to a cluster.
+type Member struct {
+	SessionID string
+	CallIndex int
+	Text      string
+}
+
+// Cluster is a group of similar responses.
+type Cluster struct {
+	Members []Member
+	// centroid is the running mean of member vectors.
+	centroid []float64
+}
+
+// Representative returns the member closest to the cluster centroid.
+func (c *Cluster) Representative() Member {
+	best, bestSim := 0, -1.0
+	for i, m := range c.Members {
+		if sim := cosine(embed(m.Text), c.centroid); sim > bestSim {
+			best, bestSim = i, sim
+		}
+	}
+	return c.Members[best]
+}
+
+// OnlyFrom reports whether every member comes from the named session.
+func (c *Cluster) OnlyFrom(sessionID string) bool {
+	for _, m := range c.Members {
+		if m.SessionID != sessionID {
+			return false
+		}
+	}
+	return true
+}
+
+// similarityThreshold is the cosine similarity above which a response
+// joins an existing cluster instead of founding a new one.
+const similarityThreshold = 0.75
+
+// Sessions clusters the assistant text of every call across the given
+// sessions. Responses are embedded with local character-trigram
+// hashing: crude next to a real embedding model, but deterministic,
+// offline and free, which is what a CI gate needs.
+func Sessions(sessions []*trace.Session) []*Cluster {
+	var clusters []*Cluster
+	for _, s := range sessions {
+		for _, c := range s.Calls {
+			text := responseText(&c)
+			if text == "" {
+				continue
+			}
+			v := embed(text)
+			member := Member{SessionID: s.ID, CallIndex: c.Index, Text: text}
+			assigned := false
+			for _, cl := range clusters {
+				if cosine(v, cl.centroid) >= similarityThreshold {
+					cl.add(member, v)
+					assigned = true
+					break
+				}
+			}
+			if !assigned {
+				clusters = append(clusters, &Cluster{
+					Members:  []Member{member},
+					centroid: v,
+				})
+			}
+		}
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return len(clusters[i].Members) > len(clusters[j].Members)
+	})
+	return clusters
+}
+
+func (c *Cluster) add(m Member, v []float64) {
+	n := float64(len(c.Members))
+	for i := range c.centroid {
+		c.centroid[i] = (c.centroid[i]*n + v[i]) / (n + 1)
+	}
+	c.Members = append(c.Members, m)
+}
+
+// responseText extracts the assistant text from a recorded call via
+// its provider parser.
+func responseText(c *trace.Call) string {
+	if len(c.Response) == 0 || c.Provider == "" {
+		return ""
+	}
+	p, err := providers.ByName(c.Provider)
+	if err != nil {
+		return ""
+	}
+	info, err := p.ParseResponse(c.Response)
+	if err != nil {
+		return ""
+	}
+	return info.Text
+}
+
+// embedDims is the hashed trigram vector size; big enough that
+// unrelated texts rarely collide into similarity.
+const embedDims = 256
+
+// embed maps text to an L2-normalized hashed character-trigram vector.
+func embed(text string) []float64 {
+	v := make([]float64, embedDims)
+	runes := []rune(text)
+	for i := 0; i+3 <= len(runes); i++ {
+		h := uint32(2166136261)
+		for _, r := range runes[i : i+3] {
+			h = (h ^ uint32(r)) * 16777619
+		}
+		v[h%embedDims]++
+	}
+	var norm float64
+	for _, x := range v {
+		norm += x * x
+	}
+	if norm > 0 {
+		norm = math.Sqrt(norm)
+		for i := range v {
+			v[i] /= norm
+		}
+	}
+	return v
+}
+
+func cosine(a, b []float64) float64 {
+	var dot float64
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+	return dot
+}
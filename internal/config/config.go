@@ -0,0 +1,337 @@
+// Package config loads the project-level .regrada.yaml file.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the per-project configuration file.
+const FileName = ".regrada.yaml"
+
+// StateDirName is the directory holding baselines, results and other
+// regrada state, relative to the project root.
+const StateDirName = ".regrada"
+
+// Config is the parsed .regrada.yaml.
+type Config struct {
+	Version int    `yaml:"version"`
+	Project string `yaml:"project,omitempty"`
+	// Env names the environment this project records and compares in
+	// (e.g. "staging"). It tags recorded sessions and keys the
+	// per-environment baseline; when empty the current git branch is
+	// used instead.
+	Env string `yaml:"env,omitempty"`
+
+	Evals     EvalsConfig               `yaml:"evals,omitempty"`
+	Baseline  BaselineConfig            `yaml:"baseline,omitempty"`
+	Artifacts ArtifactsConfig           `yaml:"artifacts,omitempty"`
+	Rewrite   []RewriteRule             `yaml:"rewrite,omitempty"`
+	Providers map[string]ProviderConfig `yaml:"providers,omitempty"`
+	CI        CIConfig                  `yaml:"ci,omitempty"`
+	Gate      GateConfig                `yaml:"gate,omitempty"`
+	Notify    NotifyConfig              `yaml:"notify,omitempty"`
+	OTel      OTelConfig                `yaml:"otel,omitempty"`
+	Judge     JudgeConfig               `yaml:"judge,omitempty"`
+	Pricing   map[string]ModelPricing   `yaml:"pricing,omitempty"`
+	Retain    RetainConfig              `yaml:"retain,omitempty"`
+	// Profiles names presets of run settings selected with `regrada
+	// run --profile <name>`, so one suite definition serves both fast
+	// PR gates and thorough scheduled runs.
+	Profiles map[string]RunProfile `yaml:"profiles,omitempty"`
+	// Hooks maps lifecycle events (run_started, test_finished,
+	// regression_detected, baseline_saved) to executables invoked with
+	// the event's JSON payload on stdin.
+	Hooks map[string][]string `yaml:"hooks,omitempty"`
+
+	// Root is the directory containing the config file. Not serialized.
+	Root string `yaml:"-"`
+}
+
+// EvalsConfig configures where test suites live and how they run.
+type EvalsConfig struct {
+	// Suite is the path to the test definitions, relative to the
+	// project root.
+	Suite string `yaml:"suite,omitempty"`
+	// Concurrent is the number of tests run in parallel.
+	Concurrent int `yaml:"concurrent,omitempty"`
+	// Plugins lists WASM evaluator modules to load, relative to the
+	// project root. Each registers a check named after its file, e.g.
+	// "evals/plugins/grounded.wasm" provides the "grounded" check.
+	Plugins []string `yaml:"plugins,omitempty"`
+}
+
+// BaselineConfig configures where baselines live beyond the local
+// state directory.
+type BaselineConfig struct {
+	// Remote is an object-storage prefix that baselines are fetched
+	// from before every run and pushed to on promotion, so CI runners
+	// share one canonical baseline. The scheme selects the backend:
+	// s3://bucket/prefix, gs://bucket/prefix or
+	// azblob://container/prefix, each resolving credentials from the
+	// provider's standard environment variables.
+	Remote string `yaml:"remote,omitempty"`
+}
+
+// ArtifactsConfig configures where run artifacts are pushed.
+type ArtifactsConfig struct {
+	// Destination is an upload URL (https://..., s3://..., file://...).
+	Destination string `yaml:"destination,omitempty"`
+}
+
+// GateConfig controls when a run fails CI.
+type GateConfig struct {
+	// FailOn selects the failure policy: "regression" (default) fails
+	// only on baseline regressions, "failure" on any failing test.
+	FailOn string `yaml:"fail_on,omitempty"`
+	// Threshold fails the run when the mean score drops below it
+	// (0 disables score gating).
+	Threshold float64 `yaml:"threshold,omitempty"`
+	// FailOnInfra also fails the run when provider infrastructure
+	// errors occur; by default they are reported but not gating.
+	FailOnInfra bool `yaml:"fail_on_infra,omitempty"`
+	// MaxCostUSD fails the run when its estimated provider cost
+	// exceeds this budget (0 disables cost gating).
+	MaxCostUSD float64 `yaml:"max_cost_usd,omitempty"`
+	// MaxCostIncreasePct fails the run when its estimated cost is more
+	// than this many percent above the baseline run's, catching changes
+	// that quietly multiply spend.
+	MaxCostIncreasePct float64 `yaml:"max_cost_increase_pct,omitempty"`
+}
+
+// ModelPricing overrides or extends the bundled pricing table for one
+// model name prefix, e.g. negotiated rates, Azure pricing or
+// self-hosted costs:
+//
+//	pricing:
+//	  gpt-4o:
+//	    input_per_mtok: 1.25
+//	    output_per_mtok: 5.00
+//	  my-local-llama:
+//	    per_call: 0.0001
+type ModelPricing struct {
+	// InputPerMTok and OutputPerMTok are USD per million prompt and
+	// completion tokens.
+	InputPerMTok  float64 `yaml:"input_per_mtok,omitempty"`
+	OutputPerMTok float64 `yaml:"output_per_mtok,omitempty"`
+	// PerCall is a flat USD cost added for every call to the model.
+	PerCall float64 `yaml:"per_call,omitempty"`
+}
+
+// NotifyConfig configures ChatOps notifications for behavioral diffs.
+type NotifyConfig struct {
+	// Webhooks lists plain HTTP endpoints that receive the full
+	// results JSON by POST after every run, for wiring regrada into
+	// pagers, bots or data pipelines.
+	Webhooks []string `yaml:"webhooks,omitempty"`
+	// WebhookSecret, when set, signs each webhook delivery with an
+	// HMAC-SHA256 signature header so receivers can authenticate it.
+	WebhookSecret string `yaml:"webhook_secret,omitempty"`
+	// SlackWebhook is an incoming-webhook URL; when set, runs with
+	// regressions post an interactive approve/reject summary.
+	SlackWebhook string `yaml:"slack_webhook,omitempty"`
+	// CallbackAddr is the listen address of the approval callback
+	// server, e.g. ":8477". Empty disables the interactive flow.
+	CallbackAddr string `yaml:"callback_addr,omitempty"`
+	// CallbackURL is the externally reachable base URL of the callback
+	// server; defaults to http://localhost<CallbackAddr>.
+	CallbackURL string `yaml:"callback_url,omitempty"`
+}
+
+// CIConfig configures the one-shot `regrada ci` pipeline.
+type CIConfig struct {
+	// Command is the app test command traced before the evals run,
+	// e.g. "pytest tests/e2e". Empty skips the tracing step.
+	Command string `yaml:"command,omitempty"`
+	// CommitStatus publishes the gate verdict as a "regrada gate"
+	// commit status on GitHub, so branch protection can require it
+	// independent of how the CI job itself exits. Needs
+	// GITHUB_REPOSITORY and GITHUB_TOKEN in the environment.
+	CommitStatus bool `yaml:"commit_status,omitempty"`
+}
+
+// RetainConfig controls run-history retention. It is applied after
+// every run and by `regrada clean --results`; when both fields are 0
+// nothing is ever removed.
+type RetainConfig struct {
+	// Runs keeps at most this many of the most recent history records.
+	Runs int `yaml:"runs,omitempty"`
+	// RollupAfterDays collapses history older than this many days to
+	// one run per day, keeping a long-term trend line cheap.
+	RollupAfterDays int `yaml:"rollup_after_days,omitempty"`
+}
+
+// RunProfile is one named preset of run settings. Each field mirrors
+// the run flag of the same name; flags given explicitly on the command
+// line still win over the profile.
+type RunProfile struct {
+	// Filter runs only tests whose name matches the glob pattern.
+	Filter string `yaml:"filter,omitempty"`
+	// Tags runs only tests carrying one of these tags.
+	Tags []string `yaml:"tags,omitempty"`
+	// Repeat runs each test N times and gates on its pass rate.
+	Repeat int `yaml:"repeat,omitempty"`
+	// DetectFlaky reruns each failing test N times to separate flaky
+	// from deterministic failures.
+	DetectFlaky int `yaml:"detect_flaky,omitempty"`
+	// Strict treats tests using unknown checks as errors.
+	Strict bool `yaml:"strict,omitempty"`
+	// Artifacts writes per-test artifacts for debugging.
+	Artifacts bool `yaml:"artifacts,omitempty"`
+	// RequireBaseline fails the run unless every test was compared
+	// against a baseline entry.
+	RequireBaseline bool `yaml:"require_baseline,omitempty"`
+}
+
+// JudgeConfig configures the model behind LLM-assisted features such
+// as `--summarize`.
+type JudgeConfig struct {
+	// Model is the OpenAI-compatible model asked to judge or
+	// summarize; OPENAI_BASE_URL redirects its calls.
+	Model string `yaml:"model,omitempty"`
+}
+
+// OTelConfig configures OpenTelemetry export of recorded sessions.
+type OTelConfig struct {
+	// Endpoint is an OTLP/HTTP collector base URL, e.g.
+	// http://localhost:4318; when set, every recorded session is also
+	// exported as gen_ai-attributed spans.
+	Endpoint string `yaml:"endpoint,omitempty"`
+}
+
+// ProviderConfig carries per-provider settings, keyed by provider name
+// ("openai", "azure-openai", ...) in the config file.
+type ProviderConfig struct {
+	// Headers are injected into every outbound request to this
+	// provider, e.g. OpenAI-Organization or a gateway auth token that
+	// the SDK does not send itself.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Endpoints lists base URLs tried in order, replacing the default
+	// upstream: the proxy fails over to the next entry on connection
+	// errors, e.g. a regional outage.
+	Endpoints []string `yaml:"endpoints,omitempty"`
+}
+
+// ProviderHeaders flattens the per-provider header config into the
+// shape the proxy consumes, nil when nothing is configured.
+func (c *Config) ProviderHeaders() map[string]map[string]string {
+	var out map[string]map[string]string
+	for name, pc := range c.Providers {
+		if len(pc.Headers) == 0 {
+			continue
+		}
+		if out == nil {
+			out = map[string]map[string]string{}
+		}
+		out[name] = pc.Headers
+	}
+	return out
+}
+
+// ProviderEndpoints flattens the per-provider failover lists into the
+// shape the proxy consumes, nil when nothing is configured.
+func (c *Config) ProviderEndpoints() map[string][]string {
+	var out map[string][]string
+	for name, pc := range c.Providers {
+		if len(pc.Endpoints) == 0 {
+			continue
+		}
+		if out == nil {
+			out = map[string][]string{}
+		}
+		endpoints := make([]string, len(pc.Endpoints))
+		for i, ep := range pc.Endpoints {
+			endpoints[i] = strings.TrimSuffix(ep, "/")
+		}
+		out[name] = endpoints
+	}
+	return out
+}
+
+// RewriteRule rewrites outgoing provider requests in the proxy:
+// dotted-path set/remove operations on the JSON body plus header
+// injection. Rules apply in order to every proxied call.
+type RewriteRule struct {
+	// Set maps dotted JSON paths to replacement values, e.g.
+	// "temperature: 0".
+	Set map[string]any `yaml:"set,omitempty"`
+	// Remove lists dotted JSON paths to delete.
+	Remove []string `yaml:"remove,omitempty"`
+	// Headers are injected into the outgoing request.
+	Headers map[string]string `yaml:"headers,omitempty"`
+}
+
+// Default returns the configuration used when no .regrada.yaml exists.
+func Default(root string) *Config {
+	return &Config{
+		Version: 1,
+		Evals: EvalsConfig{
+			Suite:      "evals/tests.yaml",
+			Concurrent: 1,
+		},
+		Root: root,
+	}
+}
+
+// Load reads the configuration for the current directory. Like git,
+// parent directories are searched upward for .regrada.yaml so commands
+// work from anywhere inside the project; when no config file is found
+// anywhere, defaults rooted at the working directory are used.
+func Load() (*Config, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	if root, ok := FindRoot(cwd); ok {
+		return LoadDir(root)
+	}
+	return Default(cwd), nil
+}
+
+// FindRoot walks up from dir looking for a directory containing
+// .regrada.yaml and reports whether one was found.
+func FindRoot(dir string) (string, bool) {
+	for {
+		if fi, err := os.Stat(filepath.Join(dir, FileName)); err == nil && fi.Mode().IsRegular() {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// LoadDir reads the configuration rooted at dir.
+func LoadDir(dir string) (*Config, error) {
+	path := filepath.Join(dir, FileName)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Default(dir), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	cfg := Default(dir)
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	cfg.Root = dir
+	return cfg, nil
+}
+
+// StateDir returns the absolute path of the project's .regrada state
+// directory.
+func (c *Config) StateDir() string {
+	return filepath.Join(c.Root, StateDirName)
+}
+
+// SuitePath returns the absolute path of the configured test suite.
+func (c *Config) SuitePath() string {
+	return filepath.Join(c.Root, c.Evals.Suite)
+}
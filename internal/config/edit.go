@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Get reads the value at a dotted key path ("gate.threshold") from the
+// config file at path. It returns the value rendered as YAML with
+// trailing whitespace trimmed.
+func Get(path, key string) (string, error) {
+	root, err := loadNode(path)
+	if err != nil {
+		return "", err
+	}
+	node := findNode(root, strings.Split(key, "."))
+	if node == nil {
+		return "", fmt.Errorf("key %q not set", key)
+	}
+	out, err := yaml.Marshal(node)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+// Set writes value at a dotted key path in the config file at path,
+// creating intermediate mappings as needed. The yaml.v3 node API is
+// used so comments and key order in the rest of the file survive.
+// Values that parse as bool, int or float are stored typed; everything
+// else is stored as a string.
+func Set(path, key, value string) error {
+	root, err := loadNode(path)
+	if err != nil {
+		return err
+	}
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: top level is not a mapping", path)
+	}
+	parts := strings.Split(key, ".")
+	node := doc
+	for _, part := range parts[:len(parts)-1] {
+		child := mappingValue(node, part)
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part},
+				child)
+		}
+		if child.Kind != yaml.MappingNode {
+			return fmt.Errorf("key %q: %s is not a mapping", key, part)
+		}
+		node = child
+	}
+	leaf := parts[len(parts)-1]
+	val := scalarNode(value)
+	if existing := mappingValue(node, leaf); existing != nil {
+		// Preserve any comment attached to the existing value.
+		val.LineComment = existing.LineComment
+		*existing = *val
+	} else {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: leaf},
+			val)
+	}
+	var buf strings.Builder
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(root.Content[0]); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(buf.String()), 0o644)
+}
+
+// loadNode parses the file into a yaml document node, synthesizing an
+// empty mapping when the file does not exist yet.
+func loadNode(path string) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		data = []byte("{}")
+	} else if err != nil {
+		return nil, err
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(root.Content) == 0 {
+		root = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+	return &root, nil
+}
+
+// findNode walks a dotted path through mapping nodes.
+func findNode(root *yaml.Node, parts []string) *yaml.Node {
+	node := root
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+	for _, part := range parts {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		node = mappingValue(node, part)
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// mappingValue returns the value node for key in a mapping, or nil.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// scalarNode builds a scalar node with an inferred YAML type.
+func scalarNode(value string) *yaml.Node {
+	n := &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+	switch {
+	case value == "true" || value == "false":
+		n.Tag = "!!bool"
+	case isInt(value):
+		n.Tag = "!!int"
+	case isFloat(value):
+		n.Tag = "!!float"
+	default:
+		n.Tag = "!!str"
+	}
+	return n
+}
+
+func isInt(s string) bool {
+	_, err := strconv.ParseInt(s, 10, 64)
+	return err == nil
+}
+
+func isFloat(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WorkspaceFileName marks the root of a monorepo containing several
+// regrada projects.
+const WorkspaceFileName = ".regrada-workspace.yaml"
+
+// Workspace lists the projects in a monorepo.
+type Workspace struct {
+	Version  int      `yaml:"version"`
+	Projects []string `yaml:"projects"`
+
+	// Root is the directory containing the workspace file.
+	Root string `yaml:"-"`
+}
+
+// FindWorkspace walks up from dir looking for a workspace file and
+// loads it. ok is false when no workspace file exists.
+func FindWorkspace(dir string) (*Workspace, bool, error) {
+	for {
+		path := filepath.Join(dir, WorkspaceFileName)
+		if fi, err := os.Stat(path); err == nil && fi.Mode().IsRegular() {
+			ws, err := loadWorkspace(path)
+			if err != nil {
+				return nil, false, err
+			}
+			return ws, true, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, false, nil
+		}
+		dir = parent
+	}
+}
+
+func loadWorkspace(path string) (*Workspace, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var ws Workspace
+	if err := yaml.Unmarshal(data, &ws); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	ws.Root = filepath.Dir(path)
+	if len(ws.Projects) == 0 {
+		return nil, fmt.Errorf("%s: no projects listed", path)
+	}
+	return &ws, nil
+}
+
+// ProjectDirs returns the absolute directories of all projects.
+func (w *Workspace) ProjectDirs() []string {
+	dirs := make([]string, len(w.Projects))
+	for i, p := range w.Projects {
+		dirs[i] = filepath.Join(w.Root, filepath.FromSlash(p))
+	}
+	return dirs
+}
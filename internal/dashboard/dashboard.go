@@ -0,0 +1,158 @@
+// Package dashboard serves a local web UI over the project's .regrada
+// state: browse trace sessions, drill into individual calls, diff the
+// latest results against the baseline, and chart cost and latency over
+// time. The UI is embedded so the binary stays self-contained.
+package dashboard
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/regrada-ai/regrada/internal/evals"
+	"github.com/regrada-ai/regrada/internal/store"
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+//go:embed index.html
+var indexHTML []byte
+
+// Server reads one project's store and answers the UI's JSON calls.
+type Server struct {
+	st *store.Store
+}
+
+// New returns a dashboard server over the store.
+func New(st *store.Store) *Server {
+	return &Server{st: st}
+}
+
+// Handler returns the UI and API handler.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(indexHTML) //nolint:errcheck // best-effort response body
+	})
+	mux.HandleFunc("/api/sessions", s.sessions)
+	mux.HandleFunc("/api/sessions/", s.session)
+	mux.HandleFunc("/api/results", s.file(s.st.ResultsPath()))
+	mux.HandleFunc("/api/baseline", s.file(s.st.ResultsBaselinePath()))
+	mux.HandleFunc("/api/history", s.history)
+	return mux
+}
+
+// sessionSummary is one row of the sessions table.
+type sessionSummary struct {
+	ID            string  `json:"id"`
+	StartedAt     string  `json:"started_at"`
+	Env           string  `json:"env,omitempty"`
+	Calls         int     `json:"calls"`
+	CostUSD       float64 `json:"cost_usd"`
+	MeanLatencyMS float64 `json:"mean_latency_ms"`
+	P95LatencyMS  float64 `json:"p95_latency_ms"`
+}
+
+func (s *Server) sessions(w http.ResponseWriter, r *http.Request) {
+	sessions, err := trace.List(s.st.SessionsDir())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	out := []sessionSummary{}
+	for _, sess := range sessions {
+		prof := trace.BuildProfile([]*trace.Session{sess})
+		out = append(out, sessionSummary{
+			ID:            sess.ID,
+			StartedAt:     sess.StartedAt.Format("2006-01-02 15:04:05"),
+			Env:           sess.Env,
+			Calls:         prof.Calls,
+			CostUSD:       prof.CostUSD,
+			MeanLatencyMS: prof.MeanLatencyMS,
+			P95LatencyMS:  prof.P95LatencyMS,
+		})
+	}
+	writeJSON(w, out)
+}
+
+func (s *Server) session(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/sessions/")
+	sess, err := trace.Find(s.st.SessionsDir(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, sess)
+}
+
+// runPoint is one point of the cost/score history chart.
+type runPoint struct {
+	RunID     string  `json:"run_id"`
+	StartedAt string  `json:"started_at"`
+	Score     float64 `json:"score"`
+	CostUSD   float64 `json:"cost_usd"`
+	Passed    int     `json:"passed"`
+	Failed    int     `json:"failed"`
+}
+
+func (s *Server) history(w http.ResponseWriter, r *http.Request) {
+	matches, err := filepath.Glob(filepath.Join(s.st.HistoryDir(), "*.json"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sort.Strings(matches)
+	out := []runPoint{}
+	for _, path := range matches {
+		res, err := evals.LoadResults(path)
+		if err != nil {
+			continue
+		}
+		passed, failed := res.Counts()
+		out = append(out, runPoint{
+			RunID:     res.RunID,
+			StartedAt: res.StartedAt.Format("2006-01-02 15:04"),
+			Score:     res.Score,
+			CostUSD:   res.CostUSD,
+			Passed:    passed,
+			Failed:    failed,
+		})
+	}
+	writeJSON(w, out)
+}
+
+// file serves a state file as-is, 404 when it does not exist yet.
+func (s *Server) file(path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data) //nolint:errcheck // best-effort response body
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, "%s\n", data) //nolint:errcheck // best-effort response body
+}
@@ -0,0 +1,37 @@
+package evals
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// WriteArtifacts writes per-test debugging artifacts for a run under
+// dir/<run-id>/<test>/: the exact prompt sent, the raw response, and
+// every check outcome with its evidence. CI failures can then be
+// debugged from uploaded artifacts without a rerun.
+func (r *Results) WriteArtifacts(dir string) (string, error) {
+	runDir := filepath.Join(dir, r.RunID)
+	for _, tr := range r.Tests {
+		testDir := filepath.Join(runDir, tr.Name)
+		if err := os.MkdirAll(testDir, 0o755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(filepath.Join(testDir, "prompt.txt"), []byte(tr.Prompt), 0o644); err != nil {
+			return "", err
+		}
+		if tr.Response != "" {
+			if err := os.WriteFile(filepath.Join(testDir, "response.txt"), []byte(tr.Response), 0o644); err != nil {
+				return "", err
+			}
+		}
+		checks, err := json.MarshalIndent(tr.Checks, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(filepath.Join(testDir, "checks.json"), append(checks, '\n'), 0o644); err != nil {
+			return "", err
+		}
+	}
+	return runDir, nil
+}
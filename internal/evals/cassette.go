@@ -0,0 +1,45 @@
+package evals
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/atomicfile"
+)
+
+// Cassette is a recorded LLM interaction for one test case. Tests
+// declaring `cassette: path.json` replay it on subsequent runs, making
+// them deterministic and offline.
+type Cassette struct {
+	RecordedAt time.Time `json:"recorded_at"`
+	Prompt     string    `json:"prompt"`
+	Response   string    `json:"response"`
+}
+
+// LoadCassette reads a cassette file.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w", err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to path, creating parent directories.
+func (c *Cassette) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(path, append(data, '\n'), 0o644)
+}
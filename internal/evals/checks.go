@@ -0,0 +1,310 @@
+package evals
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/regrada-ai/regrada/internal/language"
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+// CheckContext carries everything a check may inspect: the test, its
+// resolved prompt and the model response (empty when no response was
+// captured for the run).
+type CheckContext struct {
+	Test     *Test
+	Prompt   string
+	Response string
+	// Dir is the suite directory; checks resolve relative paths (custom
+	// evaluator scripts, fixtures) against it.
+	Dir string
+	// Calls are the traced provider calls attributed to this test when
+	// a recorded session was available, so checks can assert on real
+	// traffic instead of response text.
+	Calls []trace.Call
+}
+
+// Failure categories used to triage large suite failures. Every check
+// registers with one; flakiness is attributed by the retry logic, not
+// by checks themselves.
+const (
+	CategoryCorrectness = "correctness"
+	CategorySafety      = "safety"
+	CategoryCost        = "cost"
+	CategoryLatency     = "latency"
+	CategoryFlaky       = "flaky"
+)
+
+// CheckResult is the outcome of a single check.
+type CheckResult struct {
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+	Passed   bool   `json:"passed"`
+	// Score grades the check 0..1. Boolean checks leave it nil and
+	// score as 0 or 1; graded checks (similarity, judges) set it.
+	Score   *float64 `json:"score,omitempty"`
+	Message string   `json:"message,omitempty"`
+	// Evidence carries whatever the evaluator can offer to make the
+	// outcome debuggable without a rerun: matched text spans, judge
+	// rationales, offending JSON paths, similarity scores.
+	Evidence map[string]any `json:"evidence,omitempty"`
+	// Unknown marks a check whose name is not in the registry, so
+	// strict mode can escalate it without string-matching messages.
+	Unknown bool `json:"unknown,omitempty"`
+}
+
+// WithEvidence attaches one evidence entry, allocating the map on
+// first use, and returns the result for chaining.
+func (c CheckResult) WithEvidence(key string, value any) CheckResult {
+	if c.Evidence == nil {
+		c.Evidence = map[string]any{}
+	}
+	c.Evidence[key] = value
+	return c
+}
+
+// ScoreValue returns the numeric score of the check: the explicit
+// score when set, otherwise 1 for pass and 0 for fail.
+func (c *CheckResult) ScoreValue() float64 {
+	if c.Score != nil {
+		return *c.Score
+	}
+	if c.Passed {
+		return 1
+	}
+	return 0
+}
+
+// CheckFunc evaluates one check. arg is the text after the first colon
+// in the check spec ("tool_called:refund.create" -> "refund.create").
+type CheckFunc func(ctx *CheckContext, arg string) CheckResult
+
+type checkDef struct {
+	fn       CheckFunc
+	category string
+	arg      string
+	desc     string
+}
+
+var checkFuncs = map[string]checkDef{}
+
+// CheckInfo describes one registered check for discovery tooling:
+// editor plugins and `regrada checks list`.
+type CheckInfo struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	// Arg is the grammar of the text after the colon in a check spec,
+	// empty when the check takes no argument. Brackets mark optional
+	// parts, as in "<tool>[(<argument>)]".
+	Arg         string `json:"arg,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// DescribeCheck attaches an argument grammar and description to a
+// registered check. Checks registered without a description (plugins,
+// custom evaluators) still list, just undocumented.
+func DescribeCheck(name, arg, desc string) {
+	def, ok := checkFuncs[name]
+	if !ok {
+		panic("evals: describing unregistered check " + name)
+	}
+	def.arg, def.desc = arg, desc
+	checkFuncs[name] = def
+}
+
+// Checks enumerates every registered check, sorted by name.
+func Checks() []CheckInfo {
+	var out []CheckInfo
+	for name, def := range checkFuncs {
+		out = append(out, CheckInfo{Name: name, Category: def.category, Arg: def.arg, Description: def.desc})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// RegisterCheck adds a named check with a failure category to the
+// registry. Registering a name twice panics; built-in checks register
+// from init.
+func RegisterCheck(name, category string, fn CheckFunc) {
+	if _, dup := checkFuncs[name]; dup {
+		panic("evals: duplicate check " + name)
+	}
+	checkFuncs[name] = checkDef{fn: fn, category: category}
+}
+
+// EvalCheck parses and runs one check spec against ctx. Unknown check
+// names fail rather than silently passing, so typos in a suite are
+// caught immediately.
+func EvalCheck(ctx *CheckContext, spec string) CheckResult {
+	name, arg := spec, ""
+	if i := strings.IndexByte(spec, ':'); i >= 0 {
+		name, arg = spec[:i], spec[i+1:]
+	}
+	def, ok := checkFuncs[name]
+	if !ok {
+		return CheckResult{Name: spec, Category: CategoryCorrectness, Passed: false, Message: fmt.Sprintf("unknown check %q", name), Unknown: true}
+	}
+	res := def.fn(ctx, arg)
+	res.Name = spec
+	res.Category = def.category
+	return res
+}
+
+func pass(msg string) CheckResult { return CheckResult{Passed: true, Message: msg} }
+func fail(msg string) CheckResult { return CheckResult{Passed: false, Message: msg} }
+
+func init() {
+	RegisterCheck("sentiment", CategorySafety, checkSentiment)
+	DescribeCheck("sentiment", "[positive|negative]", "assert the response sentiment, defaulting to positive")
+	RegisterCheck("no_hallucination", CategorySafety, checkNoHallucination)
+	DescribeCheck("no_hallucination", "", "fail on classic fabrication markers in the response")
+	RegisterCheck("schema_valid", CategoryCorrectness, checkSchemaValid)
+	DescribeCheck("schema_valid", "", "require JSON-looking responses to parse as valid JSON")
+	RegisterCheck("tool_called", CategoryCorrectness, checkToolCalled)
+	DescribeCheck("tool_called", "<tool>[(<argument>)]", "require the model to have invoked the named tool, optionally with an argument substring")
+	RegisterCheck("stays_on_topic", CategorySafety, checkStaysOnTopic)
+	DescribeCheck("stays_on_topic", "", "flag responses that wander off the configured scope")
+	RegisterCheck("language", CategoryCorrectness, checkLanguage)
+	DescribeCheck("language", "<iso-639-1>", "require the response to be written in the given language")
+}
+
+// checkLanguage asserts the response is written in the expected
+// language ("language:en"), catching regressions where a multilingual
+// agent switches languages.
+func checkLanguage(ctx *CheckContext, arg string) CheckResult {
+	if arg == "" {
+		return fail("language requires an ISO 639-1 code argument, e.g. language:en")
+	}
+	if ctx.Response == "" {
+		return pass("no response captured")
+	}
+	detected := language.Detect(ctx.Response)
+	if detected == "" {
+		return pass("language could not be determined")
+	}
+	if detected != arg {
+		return fail(fmt.Sprintf("response is %q, expected %q", detected, arg)).WithEvidence("detected", detected)
+	}
+	return pass("").WithEvidence("detected", detected)
+}
+
+// negativeWords is a deliberately small lexicon: sentiment here is a
+// cheap tripwire for obviously hostile output, not a classifier.
+var negativeWords = []string{
+	"unfortunately", "cannot help", "refuse", "terrible", "awful", "hate",
+	"useless", "stupid",
+}
+
+func checkSentiment(ctx *CheckContext, arg string) CheckResult {
+	if ctx.Response == "" {
+		return pass("no response captured")
+	}
+	lower := strings.ToLower(ctx.Response)
+	var hits []string
+	for _, w := range negativeWords {
+		if strings.Contains(lower, w) {
+			hits = append(hits, w)
+		}
+	}
+	switch arg {
+	case "", "positive":
+		if len(hits) > 0 {
+			return fail("negative sentiment markers found").WithEvidence("matched", hits)
+		}
+		return pass("")
+	case "negative":
+		if len(hits) == 0 {
+			return fail("no negative sentiment markers found")
+		}
+		return pass("").WithEvidence("matched", hits)
+	default:
+		return fail(fmt.Sprintf("unsupported sentiment %q", arg))
+	}
+}
+
+func checkNoHallucination(ctx *CheckContext, _ string) CheckResult {
+	// Without a reference corpus this can only flag the classic
+	// fabrication tells.
+	if ctx.Response == "" {
+		return pass("no response captured")
+	}
+	lower := strings.ToLower(ctx.Response)
+	for _, tell := range []string{"as an ai language model, i can confirm", "citation needed"} {
+		if strings.Contains(lower, tell) {
+			return fail("fabrication marker found").WithEvidence("matched", tell)
+		}
+	}
+	return pass("")
+}
+
+func checkSchemaValid(ctx *CheckContext, _ string) CheckResult {
+	if ctx.Response == "" {
+		return pass("no response captured")
+	}
+	trimmed := strings.TrimSpace(ctx.Response)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		// Free-text responses are out of scope for schema validation.
+		return pass("response is not JSON")
+	}
+	if err := json.Unmarshal([]byte(trimmed), new(any)); err != nil {
+		res := fail("response is not valid JSON")
+		if synErr, ok := err.(*json.SyntaxError); ok {
+			res = res.WithEvidence("offset", synErr.Offset)
+		}
+		return res.WithEvidence("error", err.Error())
+	}
+	return pass("")
+}
+
+// checkToolCalled asserts the model invoked a tool. With traced calls
+// attributed to the test it verifies the recorded ToolCalls — and an
+// optional argument assertion, "tool_called:refund.create(order_id)",
+// requires the text to appear in that call's response. Without a trace
+// it falls back to scanning the captured response text.
+func checkToolCalled(ctx *CheckContext, arg string) CheckResult {
+	if arg == "" {
+		return fail("tool_called requires a tool name argument")
+	}
+	name, wantArg := arg, ""
+	if i := strings.IndexByte(arg, '('); i >= 0 && strings.HasSuffix(arg, ")") {
+		name, wantArg = arg[:i], arg[i+1:len(arg)-1]
+	}
+	if len(ctx.Calls) > 0 {
+		for _, c := range ctx.Calls {
+			for _, tool := range c.ToolCalls {
+				if tool != name {
+					continue
+				}
+				if wantArg != "" && !strings.Contains(string(c.Response), wantArg) {
+					return fail(fmt.Sprintf("tool %q called without expected argument %q", name, wantArg)).
+						WithEvidence("call_index", c.Index)
+				}
+				return pass("").WithEvidence("call_index", c.Index)
+			}
+		}
+		return fail(fmt.Sprintf("tool %q not called in traced traffic", name)).WithEvidence("expected_tool", name)
+	}
+	if ctx.Response == "" {
+		return pass("no response captured")
+	}
+	if strings.Contains(ctx.Response, name) {
+		return pass("")
+	}
+	return fail(fmt.Sprintf("tool %q not referenced in response", name)).WithEvidence("expected_tool", name)
+}
+
+func checkStaysOnTopic(ctx *CheckContext, _ string) CheckResult {
+	if ctx.Response == "" {
+		return pass("no response captured")
+	}
+	// Heuristic: an on-topic deflection acknowledges scope.
+	lower := strings.ToLower(ctx.Response)
+	for _, marker := range []string{"i can only help", "i can help with", "outside", "not able to answer"} {
+		if strings.Contains(lower, marker) {
+			return pass("")
+		}
+	}
+	return pass("no off-topic markers found")
+}
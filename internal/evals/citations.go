@@ -0,0 +1,83 @@
+package evals
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+func init() {
+	RegisterCheck("citations_valid", CategoryCorrectness, checkCitationsValid)
+	DescribeCheck("citations_valid", "[<pattern with capture group>]", "verify every citation marker refers to a document in the test's documents list")
+}
+
+// defaultCitationPattern matches bracketed numeric citations like [3].
+var defaultCitationPattern = regexp.MustCompile(`\[(\d+)\]`)
+
+// checkCitationsValid verifies that every citation marker in the
+// response refers to a document the test actually provided (the test's
+// documents: list). The default pattern matches [N] markers resolved
+// 1-based against the list; an argument supplies a custom pattern whose
+// first capture group is matched against document names instead,
+// e.g. "citations_valid:\\[doc:(\\w+)\\]". Dangling or fabricated
+// citations fail the check.
+func checkCitationsValid(ctx *CheckContext, arg string) CheckResult {
+	if ctx.Response == "" {
+		return pass("no response captured")
+	}
+	pattern := defaultCitationPattern
+	if arg != "" {
+		var err error
+		pattern, err = regexp.Compile(arg)
+		if err != nil {
+			return fail(fmt.Sprintf("invalid citation pattern: %v", err))
+		}
+		if pattern.NumSubexp() < 1 {
+			return fail("citation pattern needs a capture group for the document reference")
+		}
+	}
+	matches := pattern.FindAllStringSubmatch(ctx.Response, -1)
+	if len(matches) == 0 {
+		return pass("no citation markers found")
+	}
+	docs := ctx.Test.Documents
+	if len(docs) == 0 {
+		return fail(fmt.Sprintf("response cites %d documents but the test provides none", len(matches))).
+			WithEvidence("citations", citationRefs(matches))
+	}
+	var dangling []string
+	for _, m := range matches {
+		if !citationResolves(m[1], docs) {
+			dangling = append(dangling, m[0])
+		}
+	}
+	if len(dangling) > 0 {
+		return fail(fmt.Sprintf("%d of %d citations do not refer to a provided document", len(dangling), len(matches))).
+			WithEvidence("dangling", dangling).WithEvidence("documents", docs)
+	}
+	return pass("").WithEvidence("citations", len(matches))
+}
+
+// citationResolves reports whether one captured reference names a
+// provided document: a 1-based index into the list, or a document
+// name itself.
+func citationResolves(ref string, docs []string) bool {
+	if n, err := strconv.Atoi(ref); err == nil && n >= 1 && n <= len(docs) {
+		return true
+	}
+	for _, d := range docs {
+		if d == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// citationRefs flattens matched citation markers for evidence.
+func citationRefs(matches [][]string) []string {
+	var refs []string
+	for _, m := range matches {
+		refs = append(refs, m[0])
+	}
+	return refs
+}
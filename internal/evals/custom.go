@@ -0,0 +1,105 @@
+package evals
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// customTimeout bounds one evaluator script invocation so a hung script
+// cannot stall the whole run.
+const customTimeout = 30 * time.Second
+
+// customInput is what a custom evaluator receives as JSON on stdin.
+type customInput struct {
+	Test     string `json:"test"`
+	Prompt   string `json:"prompt"`
+	Response string `json:"response"`
+}
+
+// customVerdict is the optional JSON verdict a custom evaluator may
+// print on stdout. When absent, the exit code alone decides the
+// outcome (0 pass, non-zero fail).
+type customVerdict struct {
+	Passed   *bool          `json:"passed"`
+	Score    *float64       `json:"score,omitempty"`
+	Message  string         `json:"message,omitempty"`
+	Evidence map[string]any `json:"evidence,omitempty"`
+}
+
+func init() {
+	RegisterCheck("custom", CategoryCorrectness, checkCustom)
+	DescribeCheck("custom", "<script path>", "run an external evaluator script against the test input")
+}
+
+// checkCustom runs a user-supplied evaluator script
+// ("custom:checkers/grounded.py", resolved against the suite dir). The
+// script gets the test, prompt and response as JSON on stdin and either
+// prints a JSON verdict or signals the outcome via its exit code.
+func checkCustom(ctx *CheckContext, arg string) CheckResult {
+	if arg == "" {
+		return fail("custom requires a script path argument, e.g. custom:checkers/grounded.py")
+	}
+	script := arg
+	if !filepath.IsAbs(script) {
+		script = filepath.Join(ctx.Dir, script)
+	}
+	input, err := json.Marshal(customInput{Test: ctx.Test.Name, Prompt: ctx.Prompt, Response: ctx.Response})
+	if err != nil {
+		return fail(fmt.Sprintf("encoding evaluator input: %v", err))
+	}
+
+	cctx, cancel := context.WithTimeout(context.Background(), customTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(cctx, script)
+	cmd.Dir = ctx.Dir
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+	if cctx.Err() == context.DeadlineExceeded {
+		return fail(fmt.Sprintf("evaluator %s timed out after %s", arg, customTimeout))
+	}
+
+	res := CheckResult{Passed: runErr == nil}
+	if runErr != nil {
+		if _, ok := runErr.(*exec.ExitError); !ok {
+			// The script could not be started at all; that is a suite
+			// configuration error, not a model failure.
+			return fail(fmt.Sprintf("running evaluator %s: %v", arg, runErr))
+		}
+		res.Message = fmt.Sprintf("evaluator %s failed: %v", arg, runErr)
+	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		res = res.WithEvidence("stderr", msg)
+	}
+
+	out := strings.TrimSpace(stdout.String())
+	if out == "" {
+		return res
+	}
+	var verdict customVerdict
+	if err := json.Unmarshal([]byte(out), &verdict); err != nil {
+		// Plain-text output becomes the message; the exit code already
+		// decided the outcome.
+		res.Message = out
+		return res
+	}
+	if verdict.Passed != nil {
+		res.Passed = *verdict.Passed
+	}
+	res.Score = verdict.Score
+	if verdict.Message != "" {
+		res.Message = verdict.Message
+	}
+	for k, v := range verdict.Evidence {
+		res = res.WithEvidence(k, v)
+	}
+	return res
+}
@@ -0,0 +1,27 @@
+package evals
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// IsInfraError reports whether a captured response is a provider
+// infrastructure error payload (rate limit, auth failure, server
+// error) rather than model output. Providers — and the chaos proxy —
+// all surface these as a JSON body with a top-level error object.
+func IsInfraError(response string) bool {
+	trimmed := strings.TrimSpace(response)
+	if !strings.HasPrefix(trimmed, "{") {
+		return false
+	}
+	var payload struct {
+		Error *struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(trimmed), &payload); err != nil {
+		return false
+	}
+	return payload.Error != nil && (payload.Error.Type != "" || payload.Error.Message != "")
+}
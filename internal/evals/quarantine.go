@@ -0,0 +1,68 @@
+package evals
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/atomicfile"
+)
+
+// Quarantine lists tests whose failures are triaged as flaky rather
+// than behavioral. Quarantined failures never gate a run or count as
+// regressions; the list is typically fed by `regrada review`.
+type Quarantine struct {
+	Tests map[string]QuarantinedTest `json:"tests"`
+}
+
+// QuarantinedTest records why and when a test was quarantined.
+type QuarantinedTest struct {
+	Reason  string    `json:"reason,omitempty"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// LoadQuarantine reads the quarantine file; a missing file is an empty
+// quarantine, not an error.
+func LoadQuarantine(path string) (*Quarantine, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Quarantine{Tests: map[string]QuarantinedTest{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading quarantine: %w", err)
+	}
+	var q Quarantine
+	if err := json.Unmarshal(data, &q); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if q.Tests == nil {
+		q.Tests = map[string]QuarantinedTest{}
+	}
+	return &q, nil
+}
+
+// Save writes the quarantine to path as indented JSON.
+func (q *Quarantine) Save(path string) error {
+	data, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Add quarantines the named test.
+func (q *Quarantine) Add(name, reason string) {
+	q.Tests[name] = QuarantinedTest{Reason: reason, AddedAt: time.Now().UTC()}
+}
+
+// Remove lifts the quarantine on the named test.
+func (q *Quarantine) Remove(name string) {
+	delete(q.Tests, name)
+}
+
+// Has reports whether the named test is quarantined.
+func (q *Quarantine) Has(name string) bool {
+	_, ok := q.Tests[name]
+	return ok
+}
@@ -0,0 +1,170 @@
+package evals
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/atomicfile"
+)
+
+// Test statuses as recorded in results and baselines.
+const (
+	StatusPass = "pass"
+	StatusFail = "fail"
+	// StatusError marks a test the runner could not evaluate properly,
+	// e.g. one using an unknown check under strict mode.
+	StatusError = "error"
+	// StatusInfraError marks a test whose response was a provider
+	// infrastructure error (rate limit, auth, server error) rather
+	// than model output: not a behavioral failure.
+	StatusInfraError = "infra_error"
+	// StatusSkipped marks a test that never ran: skip: true in the
+	// suite, or the suite's wall-clock budget was exhausted first.
+	StatusSkipped = "skipped"
+	// StatusXFail marks an xfail test that failed as expected;
+	// StatusXPass one that unexpectedly passed. Neither gates.
+	StatusXFail = "xfail"
+	StatusXPass = "xpass"
+)
+
+// ResultsSchemaVersion is the current results file schema. Files
+// stamped with a higher version are rejected instead of silently
+// mis-parsed.
+const ResultsSchemaVersion = 1
+
+// Baseline statuses as recorded in results: what this run was (or was
+// not) compared against.
+const (
+	// BaselineMissing means no baseline existed; regression detection
+	// was skipped entirely.
+	BaselineMissing = "missing"
+	// BaselineStale means a baseline existed but did not cover every
+	// test in this run, so some tests went uncompared.
+	BaselineStale = "stale"
+	// BaselineOK means every test was compared against a baseline entry.
+	BaselineOK = "ok"
+)
+
+// Results records one eval run.
+type Results struct {
+	// Schema is the schema version the file was written with, stamped
+	// on save; zero marks files from before versioning.
+	Schema         int    `json:"schema_version,omitempty"`
+	RunID          string `json:"run_id"`
+	RegradaVersion string `json:"regrada_version,omitempty"`
+
+	Suite     string    `json:"suite"`
+	StartedAt time.Time `json:"started_at"`
+	Duration  float64   `json:"duration_seconds"`
+	// Score is the mean test score over executed tests, 0..1.
+	Score float64      `json:"score"`
+	Tests []TestResult `json:"tests"`
+	// BudgetExceeded records that the suite's max_duration ran out and
+	// the remaining tests were skipped.
+	BudgetExceeded bool `json:"budget_exceeded,omitempty"`
+	// BaselineStatus records whether the run was compared against a
+	// baseline: BaselineMissing, BaselineStale or BaselineOK. Empty in
+	// files from before the field existed.
+	BaselineStatus string `json:"baseline_status,omitempty"`
+	// ChangeSummary is the judge model's paragraph describing how
+	// behavior changed against the baseline, set by --summarize.
+	ChangeSummary string `json:"change_summary,omitempty"`
+	// CostUSD is the estimated provider cost of the traffic behind the
+	// run, from the pricing table; zero when no trace was available.
+	CostUSD float64 `json:"cost_usd,omitempty"`
+}
+
+// TestResult is the outcome of one test case.
+type TestResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// Score is the mean check score, 0..1.
+	Score    float64       `json:"score"`
+	Checks   []CheckResult `json:"checks"`
+	Duration float64       `json:"duration_seconds"`
+	// Prompt and Response record what was actually evaluated so
+	// failures can be explained after the fact.
+	Prompt   string `json:"prompt,omitempty"`
+	Response string `json:"response,omitempty"`
+	// Reason explains a skipped test or a missed pass-rate threshold.
+	Reason string `json:"reason,omitempty"`
+	// Flaky marks a test whose outcome changed across attempts: it
+	// passed only after retries, or a --detect-flaky rerun of its
+	// failure passed. Flaky failures do not count as regressions.
+	Flaky bool `json:"flaky,omitempty"`
+	// Attempts is how many times the test ran, 0 meaning a single
+	// attempt with no retry or repeat policy involved.
+	Attempts int `json:"attempts,omitempty"`
+	// PassRate is the fraction of repeat runs that passed, set only for
+	// repeated tests.
+	PassRate *float64 `json:"pass_rate,omitempty"`
+}
+
+// Test returns the result for the named test, or nil.
+func (r *Results) Test(name string) *TestResult {
+	for i := range r.Tests {
+		if r.Tests[i].Name == name {
+			return &r.Tests[i]
+		}
+	}
+	return nil
+}
+
+// Counts returns the number of passed and failed tests. Skipped and
+// expected-failure tests count as neither.
+func (r *Results) Counts() (passed, failed int) {
+	for _, t := range r.Tests {
+		switch t.Status {
+		case StatusPass, StatusXPass:
+			passed++
+		case StatusSkipped, StatusXFail:
+		default:
+			failed++
+		}
+	}
+	return
+}
+
+// NewRunID returns a sortable, unique run identifier.
+func NewRunID() string {
+	var b [4]byte
+	_, _ = rand.Read(b[:])
+	return time.Now().UTC().Format("20060102-150405") + "-" + hex.EncodeToString(b[:])
+}
+
+// LoadResults reads a results file. Files of a different shape (for
+// example a trace session or status baseline) are rejected instead of
+// silently unmarshalling into garbage.
+func LoadResults(path string) (*Results, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading results: %w", err)
+	}
+	var r Results
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if r.RunID == "" {
+		return nil, fmt.Errorf("%s is not an eval results file (no run_id)", path)
+	}
+	if r.Schema > ResultsSchemaVersion {
+		return nil, fmt.Errorf("%s uses results schema v%d; this regrada understands up to v%d — upgrade regrada", path, r.Schema, ResultsSchemaVersion)
+	}
+	return &r, nil
+}
+
+// Save writes the results to path as indented JSON.
+func (r *Results) Save(path string) error {
+	if r.Schema == 0 {
+		r.Schema = ResultsSchemaVersion
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,378 @@
+package evals
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/trace"
+	"github.com/regrada-ai/regrada/internal/version"
+)
+
+// Runner executes test cases from a suite.
+type Runner struct {
+	Suite *Suite
+	// Strict escalates tests using unknown checks to StatusError. It
+	// defaults to the suite's strict option.
+	Strict bool
+	// ReRecord refreshes cassettes instead of replaying them.
+	ReRecord bool
+	// Trace is a recorded session whose calls are attributed to tests
+	// (by prompt) so trace-aware checks like tool_called inspect real
+	// traffic instead of response text.
+	Trace *trace.Session
+	// Concurrent caps how many tests run at once (evals.concurrent in
+	// the config). Values below 2 keep the serial path.
+	Concurrent int
+	// Filter restricts a full run to tests whose name matches the glob
+	// pattern; Tags to tests carrying at least one of the tags. Both
+	// are ignored when explicit names are given.
+	Filter string
+	Tags   []string
+	// DetectFlaky reruns each failing test this many times to separate
+	// flaky from deterministic failures.
+	DetectFlaky int
+	// Repeat is the default repeat count for tests that do not set
+	// their own (the --repeat flag); values below 2 leave single runs.
+	Repeat int
+}
+
+// NewRunner returns a Runner over the given suite.
+func NewRunner(s *Suite) *Runner {
+	return &Runner{Suite: s, Strict: s.Strict}
+}
+
+// Run executes the named tests, or every test in the suite when names
+// is empty, and returns the collected results.
+func (r *Runner) Run(names []string) (*Results, error) {
+	tests, err := r.selectTests(names)
+	if err != nil {
+		return nil, err
+	}
+	res := &Results{
+		RunID:          NewRunID(),
+		RegradaVersion: version.Version,
+		Suite:          r.Suite.Name,
+		StartedAt:      time.Now().UTC(),
+	}
+	start := time.Now()
+	if err := r.runAll(tests, res, start); err != nil {
+		return nil, err
+	}
+	res.Duration = time.Since(start).Seconds()
+	var sum float64
+	executed := 0
+	for _, tr := range res.Tests {
+		if tr.Status == StatusSkipped {
+			continue
+		}
+		sum += tr.Score
+		executed++
+	}
+	if executed > 0 {
+		res.Score = sum / float64(executed)
+	}
+	return res, nil
+}
+
+// runAll executes the tests — in a worker pool when Concurrent allows
+// it — and appends their results to res in suite order, so parallel
+// runs stay byte-for-byte comparable with serial ones. Tests the
+// wall-clock budget cuts off are recorded as skipped.
+func (r *Runner) runAll(tests []*Test, res *Results, start time.Time) error {
+	results := make([]TestResult, len(tests))
+	errs := make([]error, len(tests))
+	workers := r.Concurrent
+	if workers < 1 {
+		workers = 1
+	}
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, t := range tests {
+		if r.Suite.Budget > 0 && time.Since(start) > r.Suite.Budget {
+			// Budget exhausted: stop launching tests and record the rest
+			// as skipped so the run still terminates with a full roster.
+			res.BudgetExceeded = true
+			results[i] = TestResult{Name: t.Name, Status: StatusSkipped}
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, t *Test) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errs[i] = r.runTestWithRetries(t)
+		}(i, t)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	res.Tests = append(res.Tests, results...)
+	return nil
+}
+
+func (r *Runner) selectTests(names []string) ([]*Test, error) {
+	if len(names) == 0 {
+		var tests []*Test
+		for i := range r.Suite.Tests {
+			t := &r.Suite.Tests[i]
+			if r.selected(t) {
+				tests = append(tests, t)
+			}
+		}
+		if len(tests) == 0 && (r.Filter != "" || len(r.Tags) > 0) {
+			return nil, fmt.Errorf("no tests in suite %s match the filter", r.Suite.Name)
+		}
+		return tests, nil
+	}
+	var tests []*Test
+	for _, name := range names {
+		t := r.Suite.Test(name)
+		if t == nil {
+			return nil, fmt.Errorf("no test named %q in suite %s", name, r.Suite.Name)
+		}
+		tests = append(tests, t)
+	}
+	return tests, nil
+}
+
+// runTestWithRetries wraps runTest with the test's retry policy and
+// the runner's flaky detection. A failure that passes on a retry
+// becomes a flaky pass; a failure whose detection reruns ever pass is
+// kept failing but flagged flaky so it is not treated as a regression.
+func (r *Runner) runTestWithRetries(t *Test) (TestResult, error) {
+	if repeat := r.repeatFor(t); repeat > 1 {
+		return r.runRepeated(t, repeat)
+	}
+	tr, err := r.runTest(t)
+	if err != nil || tr.Status != StatusFail {
+		return tr, err
+	}
+	attempts := 1
+	for n := 0; n < t.Retries; n++ {
+		retr, err := r.runTest(t)
+		if err != nil {
+			return tr, err
+		}
+		attempts++
+		if retr.Status == StatusPass {
+			retr.Flaky = true
+			retr.Attempts = attempts
+			return retr, nil
+		}
+		tr = retr
+	}
+	for n := 0; n < r.DetectFlaky; n++ {
+		retr, err := r.runTest(t)
+		if err != nil {
+			return tr, err
+		}
+		attempts++
+		if retr.Status == StatusPass {
+			tr.Flaky = true
+			break
+		}
+	}
+	if attempts > 1 {
+		tr.Attempts = attempts
+	}
+	return tr, nil
+}
+
+// repeatFor returns the effective repeat count for a test: its own
+// repeat: when set, otherwise the runner's --repeat default.
+func (r *Runner) repeatFor(t *Test) int {
+	if t.Repeat > 0 {
+		return t.Repeat
+	}
+	return r.Repeat
+}
+
+// runRepeated runs a statistical test: repeat samples whose pass rate
+// is gated against the test's min_pass_rate (default 1, every sample
+// must pass). The reported result is the last failing sample when the
+// threshold is missed, so its checks explain a real failure, and the
+// score is the mean over all samples. Repetition replaces the retry
+// policy: a repeated test already encodes its tolerance for flakiness.
+func (r *Runner) runRepeated(t *Test, repeat int) (TestResult, error) {
+	min := t.MinPassRate
+	if min <= 0 {
+		min = 1
+	}
+	var last, lastFail TestResult
+	passed := 0
+	var scoreSum float64
+	for n := 0; n < repeat; n++ {
+		tr, err := r.runTest(t)
+		if err != nil {
+			return tr, err
+		}
+		switch tr.Status {
+		case StatusPass:
+			passed++
+		case StatusFail:
+			lastFail = tr
+		default:
+			// Skips, infra errors and xfail outcomes are not statistical:
+			// report the first one as-is.
+			return tr, nil
+		}
+		scoreSum += tr.Score
+		last = tr
+	}
+	rate := float64(passed) / float64(repeat)
+	tr := last
+	if rate < min {
+		tr = lastFail
+		tr.Status = StatusFail
+		tr.Reason = fmt.Sprintf("pass rate %.0f%% below %.0f%%", rate*100, min*100)
+	} else {
+		tr.Status = StatusPass
+		tr.Reason = ""
+	}
+	tr.Score = scoreSum / float64(repeat)
+	tr.Attempts = repeat
+	tr.PassRate = &rate
+	return tr, nil
+}
+
+// selected applies the name glob and tag filters to one test.
+func (r *Runner) selected(t *Test) bool {
+	if r.Filter != "" {
+		if ok, err := path.Match(r.Filter, t.Name); err != nil || !ok {
+			return false
+		}
+	}
+	if len(r.Tags) == 0 {
+		return true
+	}
+	for _, want := range r.Tags {
+		for _, tag := range t.Tags {
+			if tag == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *Runner) runTest(t *Test) (TestResult, error) {
+	if t.Skip {
+		return TestResult{Name: t.Name, Status: StatusSkipped, Reason: t.SkipReason}, nil
+	}
+	start := time.Now()
+	prompt, err := r.Suite.ResolvePrompt(t)
+	if err != nil {
+		return TestResult{}, fmt.Errorf("test %s: %w", t.Name, err)
+	}
+	ctx := &CheckContext{Test: t, Prompt: prompt, Dir: r.Suite.Dir}
+	if r.Trace != nil {
+		ctx.Calls = attributeCalls(r.Trace, prompt)
+	}
+	tr := TestResult{Name: t.Name, Status: StatusPass, Prompt: prompt}
+	var scoreSum float64
+	if t.Cassette != "" {
+		cr, err := r.applyCassette(t, ctx)
+		if err != nil {
+			return TestResult{}, fmt.Errorf("test %s: %w", t.Name, err)
+		}
+		if cr != nil {
+			tr.Checks = append(tr.Checks, *cr)
+			scoreSum += cr.ScoreValue()
+			if !cr.Passed {
+				tr.Status = StatusFail
+			}
+		}
+	}
+	tr.Response = ctx.Response
+	for _, spec := range t.Checks {
+		cr := EvalCheck(ctx, spec)
+		tr.Checks = append(tr.Checks, cr)
+		scoreSum += cr.ScoreValue()
+		if !cr.Passed && tr.Status == StatusPass {
+			tr.Status = StatusFail
+		}
+		if cr.Unknown && r.Strict {
+			tr.Status = StatusError
+		}
+	}
+	if len(tr.Checks) > 0 {
+		tr.Score = scoreSum / float64(len(tr.Checks))
+	} else {
+		tr.Score = 1
+	}
+	// A provider error payload means the test never really ran:
+	// classify it as infrastructure, not behavior.
+	if IsInfraError(ctx.Response) {
+		tr.Status = StatusInfraError
+	}
+	if t.XFail {
+		switch tr.Status {
+		case StatusFail:
+			tr.Status = StatusXFail
+		case StatusPass:
+			tr.Status = StatusXPass
+		}
+	}
+	tr.Duration = time.Since(start).Seconds()
+	return tr, nil
+}
+
+// attributeCalls returns the session calls carrying the test's prompt
+// in their request body. The prompt is matched in its JSON-escaped
+// form, since that is how it appears on the wire.
+func attributeCalls(s *trace.Session, prompt string) []trace.Call {
+	prompt = strings.TrimSpace(prompt)
+	if prompt == "" {
+		return nil
+	}
+	escaped, err := json.Marshal(prompt)
+	if err != nil {
+		return nil
+	}
+	needle := string(escaped[1 : len(escaped)-1])
+	var calls []trace.Call
+	for _, c := range s.Calls {
+		if strings.Contains(string(c.Request), needle) {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// applyCassette replays or records the test's cassette. When a
+// recording exists (and ReRecord is off) it becomes the response under
+// evaluation; a prompt that no longer matches the recording is
+// reported as a behavioral change. Otherwise the current interaction
+// is recorded for later runs.
+func (r *Runner) applyCassette(t *Test, ctx *CheckContext) (*CheckResult, error) {
+	path := filepath.Join(r.Suite.Dir, t.Cassette)
+	if _, err := os.Stat(path); err == nil && !r.ReRecord {
+		cas, err := LoadCassette(path)
+		if err != nil {
+			return nil, err
+		}
+		ctx.Response = cas.Response
+		if cas.Prompt != ctx.Prompt {
+			cr := CheckResult{
+				Name:     "cassette",
+				Category: CategoryCorrectness,
+				Passed:   false,
+				Message:  "prompt changed since the cassette was recorded; refresh with --re-record",
+			}
+			cr = cr.WithEvidence("cassette", t.Cassette).WithEvidence("recorded_at", cas.RecordedAt)
+			return &cr, nil
+		}
+		return nil, nil
+	}
+	cas := &Cassette{RecordedAt: time.Now().UTC(), Prompt: ctx.Prompt, Response: ctx.Response}
+	return nil, cas.Save(path)
+}
@@ -0,0 +1,113 @@
+package evals
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterCheck("format", CategoryCorrectness, checkFormat)
+	DescribeCheck("format", "json_only|markdown", "validate the structural presentation of the response")
+	RegisterCheck("no_preamble", CategoryCorrectness, checkNoPreamble)
+	DescribeCheck("no_preamble", "", "fail responses that open with a conversational preamble")
+}
+
+// checkFormat validates the structural presentation of the response:
+// "format:json_only" requires the response to be exactly one JSON
+// document with no surrounding prose, "format:markdown" requires
+// recognizable markdown structure.
+func checkFormat(ctx *CheckContext, arg string) CheckResult {
+	switch arg {
+	case "json_only":
+		return checkJSONOnly(ctx)
+	case "markdown":
+		return checkMarkdown(ctx)
+	case "":
+		return fail("format requires an argument, e.g. format:json_only")
+	default:
+		return fail(fmt.Sprintf("unsupported format %q", arg))
+	}
+}
+
+// checkJSONOnly fails when the response is anything other than a single
+// JSON value — the classic regression being the model wrapping JSON in
+// prose or a markdown fence.
+func checkJSONOnly(ctx *CheckContext) CheckResult {
+	if ctx.Response == "" {
+		return pass("no response captured")
+	}
+	trimmed := strings.TrimSpace(ctx.Response)
+	if strings.HasPrefix(trimmed, "```") {
+		return fail("response wraps JSON in a markdown fence").
+			WithEvidence("prefix", firstLine(trimmed))
+	}
+	dec := json.NewDecoder(strings.NewReader(trimmed))
+	if err := dec.Decode(new(any)); err != nil {
+		return fail("response is not a JSON document").
+			WithEvidence("prefix", firstLine(trimmed)).WithEvidence("error", err.Error())
+	}
+	if dec.More() || strings.TrimSpace(trimmed[dec.InputOffset():]) != "" {
+		return fail("response has trailing text after the JSON document").
+			WithEvidence("trailing", firstLine(strings.TrimSpace(trimmed[dec.InputOffset():])))
+	}
+	return pass("")
+}
+
+// checkMarkdown passes when the response carries markdown structure:
+// a heading, list, code fence, emphasis or link.
+func checkMarkdown(ctx *CheckContext) CheckResult {
+	if ctx.Response == "" {
+		return pass("no response captured")
+	}
+	for _, line := range strings.Split(ctx.Response, "\n") {
+		l := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(l, "#"),
+			strings.HasPrefix(l, "- "),
+			strings.HasPrefix(l, "* "),
+			strings.HasPrefix(l, "```"):
+			return pass("")
+		}
+	}
+	for _, marker := range []string{"**", "](", "`"} {
+		if strings.Contains(ctx.Response, marker) {
+			return pass("")
+		}
+	}
+	return fail("no markdown structure found in response")
+}
+
+// preambles are the conversational openers that signal the model is
+// narrating instead of answering.
+var preambles = []string{
+	"sure", "certainly", "of course", "absolutely", "great question",
+	"here is", "here's", "as an ai", "i'd be happy to", "i would be happy to",
+}
+
+// checkNoPreamble fails when the response opens with a conversational
+// preamble rather than the answer itself.
+func checkNoPreamble(ctx *CheckContext, _ string) CheckResult {
+	if ctx.Response == "" {
+		return pass("no response captured")
+	}
+	lower := strings.ToLower(strings.TrimSpace(ctx.Response))
+	for _, p := range preambles {
+		if strings.HasPrefix(lower, p) {
+			return fail("response opens with a preamble").WithEvidence("matched", p)
+		}
+	}
+	return pass("")
+}
+
+// firstLine returns the first line of s, truncated for evidence use.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	const max = 80
+	if len(s) > max {
+		s = s[:max] + "..."
+	}
+	return s
+}
@@ -0,0 +1,125 @@
+// Package evals loads test suites and evaluates their checks against
+// model behavior.
+package evals
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Suite is a parsed test suite file (evals/tests.yaml).
+type Suite struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	// Strict marks tests using unknown checks as errors instead of
+	// plain failures. The run command's --strict flag has the same
+	// effect for one run.
+	Strict bool `yaml:"strict,omitempty"`
+	// MaxDuration is a wall-clock budget for the whole run ("15m").
+	// When it runs out, tests not yet started are skipped so CI jobs
+	// cannot hang indefinitely.
+	MaxDuration string `yaml:"max_duration,omitempty"`
+	Tests       []Test `yaml:"tests"`
+
+	// Budget is MaxDuration parsed, zero when unset. Not serialized.
+	Budget time.Duration `yaml:"-"`
+
+	// Dir is the directory containing the suite file, used to resolve
+	// prompt file references. Not serialized.
+	Dir string `yaml:"-"`
+}
+
+// Test is a single test case.
+type Test struct {
+	Name   string `yaml:"name"`
+	Prompt string `yaml:"prompt"`
+	// Cassette names a recorded-interaction file relative to the suite
+	// directory. The first run records it; later runs replay it.
+	Cassette string   `yaml:"cassette,omitempty"`
+	Checks   []string `yaml:"checks"`
+	// Documents lists the context documents supplied to the model for
+	// RAG tests, by id or name, so citation checks can verify that
+	// every citation in the response refers to one of them.
+	Documents []string `yaml:"documents,omitempty"`
+	// Tags label the test for subset selection (--tags smoke), so one
+	// suite serves both quick pushes and the full nightly run.
+	Tags []string `yaml:"tags,omitempty"`
+	// Retries reruns the test on failure up to this many times; a pass
+	// on any attempt counts, flagged as flaky in the results.
+	Retries int `yaml:"retries,omitempty"`
+	// Repeat runs the test this many times and gates on the pass rate
+	// instead of a single sample, since model output is stochastic.
+	// MinPassRate (0..1, default 1) is the rate required to pass.
+	Repeat      int     `yaml:"repeat,omitempty"`
+	MinPassRate float64 `yaml:"min_pass_rate,omitempty"`
+	// Skip excludes the test from runs; SkipReason says why, so the
+	// suite tracks known issues instead of deleting tests.
+	Skip       bool   `yaml:"skip,omitempty"`
+	SkipReason string `yaml:"skip_reason,omitempty"`
+	// XFail marks the test as expected to fail: a failure is recorded
+	// as xfail and does not gate, an unexpected pass as xpass.
+	XFail bool `yaml:"xfail,omitempty"`
+}
+
+// LoadSuite reads and validates a suite file.
+func LoadSuite(path string) (*Suite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading suite: %w", err)
+	}
+	var s Suite
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	s.Dir = filepath.Dir(path)
+	if s.MaxDuration != "" {
+		s.Budget, err = time.ParseDuration(s.MaxDuration)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid max_duration %q", path, s.MaxDuration)
+		}
+	}
+	seen := map[string]bool{}
+	for _, t := range s.Tests {
+		if t.Name == "" {
+			return nil, fmt.Errorf("%s: test with empty name", path)
+		}
+		if seen[t.Name] {
+			return nil, fmt.Errorf("%s: duplicate test name %q", path, t.Name)
+		}
+		seen[t.Name] = true
+	}
+	return &s, nil
+}
+
+// Test returns the named test, or nil if the suite has no such test.
+func (s *Suite) Test(name string) *Test {
+	for i := range s.Tests {
+		if s.Tests[i].Name == name {
+			return &s.Tests[i]
+		}
+	}
+	return nil
+}
+
+// ResolvePrompt returns the prompt text for t. A single-line prompt
+// naming an existing file relative to the suite directory is read from
+// that file; anything else is used verbatim.
+func (s *Suite) ResolvePrompt(t *Test) (string, error) {
+	p := t.Prompt
+	if !strings.Contains(strings.TrimSpace(p), "\n") {
+		path := filepath.Join(s.Dir, strings.TrimSpace(p))
+		if fi, err := os.Stat(path); err == nil && fi.Mode().IsRegular() {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return "", fmt.Errorf("reading prompt file: %w", err)
+			}
+			return string(data), nil
+		}
+	}
+	return p, nil
+}
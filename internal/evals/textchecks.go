@@ -0,0 +1,78 @@
+package evals
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+func init() {
+	RegisterCheck("matches", CategoryCorrectness, checkMatches)
+	DescribeCheck("matches", "<regexp>", "require the response to match the regular expression")
+	RegisterCheck("contains", CategoryCorrectness, checkContains)
+	DescribeCheck("contains", "[(?i)]<substring>", "require the response to contain the text, case-insensitively with a (?i) prefix")
+	RegisterCheck("not_contains", CategoryCorrectness, checkNotContains)
+	DescribeCheck("not_contains", "[(?i)]<substring>", "forbid the text from appearing in the response")
+}
+
+// checkMatches asserts the response matches a Go regular expression
+// ("matches:order #\\d+"). Prefix the pattern with (?i) for
+// case-insensitive matching.
+func checkMatches(ctx *CheckContext, arg string) CheckResult {
+	if arg == "" {
+		return fail("matches requires a regex argument, e.g. matches:order #\\d+")
+	}
+	re, err := regexp.Compile(arg)
+	if err != nil {
+		return fail(fmt.Sprintf("invalid regex %q: %v", arg, err))
+	}
+	if ctx.Response == "" {
+		return pass("no response captured")
+	}
+	if loc := re.FindStringIndex(ctx.Response); loc != nil {
+		return pass("").WithEvidence("matched", ctx.Response[loc[0]:loc[1]])
+	}
+	return fail(fmt.Sprintf("response does not match %q", arg))
+}
+
+// foldContains reports whether haystack contains needle, honoring an
+// optional (?i) prefix on needle for case-insensitive comparison — the
+// same switch the matches check inherits from regex syntax.
+func foldContains(haystack, needle string) (string, bool) {
+	if folded, ok := strings.CutPrefix(needle, "(?i)"); ok {
+		return folded, strings.Contains(strings.ToLower(haystack), strings.ToLower(folded))
+	}
+	return needle, strings.Contains(haystack, needle)
+}
+
+// checkContains asserts the response contains a literal substring
+// ("contains:refund", "contains:(?i)refund").
+func checkContains(ctx *CheckContext, arg string) CheckResult {
+	if arg == "" {
+		return fail("contains requires a substring argument")
+	}
+	if ctx.Response == "" {
+		return pass("no response captured")
+	}
+	needle, found := foldContains(ctx.Response, arg)
+	if !found {
+		return fail(fmt.Sprintf("response does not contain %q", needle))
+	}
+	return pass("")
+}
+
+// checkNotContains asserts the response does not contain a literal
+// substring ("not_contains:(?i)as an ai language model").
+func checkNotContains(ctx *CheckContext, arg string) CheckResult {
+	if arg == "" {
+		return fail("not_contains requires a substring argument")
+	}
+	if ctx.Response == "" {
+		return pass("no response captured")
+	}
+	needle, found := foldContains(ctx.Response, arg)
+	if found {
+		return fail(fmt.Sprintf("response contains forbidden %q", needle)).WithEvidence("matched", needle)
+	}
+	return pass("")
+}
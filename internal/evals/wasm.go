@@ -0,0 +1,113 @@
+package evals
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// wasmPlugins tracks which paths registered which check names, so the
+// same project config can be loaded repeatedly (e.g. --all-projects)
+// without tripping the duplicate-registration panic.
+var wasmPlugins = map[string]string{}
+
+// RegisterWASMPlugin compiles a WASI evaluator module and registers it
+// as a check under name. The module speaks the same protocol as
+// custom: evaluator scripts — input JSON on stdin, optional verdict
+// JSON on stdout, exit code as the fallback outcome — but runs
+// sandboxed with no filesystem or network access.
+func RegisterWASMPlugin(name, path string) error {
+	if prev, ok := wasmPlugins[name]; ok {
+		if prev == path {
+			return nil
+		}
+		return fmt.Errorf("wasm plugin %q already loaded from %s", name, prev)
+	}
+	if _, ok := checkFuncs[name]; ok {
+		return fmt.Errorf("wasm plugin %q collides with a registered check", name)
+	}
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading wasm plugin %q: %w", name, err)
+	}
+	ctx := context.Background()
+	runtime := wazero.NewRuntime(ctx)
+	wasi_snapshot_preview1.MustInstantiate(ctx, runtime)
+	compiled, err := runtime.CompileModule(ctx, code)
+	if err != nil {
+		runtime.Close(ctx)
+		return fmt.Errorf("compiling wasm plugin %q: %w", name, err)
+	}
+	wasmPlugins[name] = path
+	RegisterCheck(name, CategoryCorrectness, func(cctx *CheckContext, arg string) CheckResult {
+		return runWASMCheck(runtime, compiled, name, cctx, arg)
+	})
+	return nil
+}
+
+// runWASMCheck instantiates the compiled module for one evaluation. A
+// fresh instance per call keeps plugin state from leaking between
+// tests.
+func runWASMCheck(runtime wazero.Runtime, compiled wazero.CompiledModule, name string, cctx *CheckContext, arg string) CheckResult {
+	input, err := json.Marshal(customInput{Test: cctx.Test.Name, Prompt: cctx.Prompt, Response: cctx.Response})
+	if err != nil {
+		return fail(fmt.Sprintf("encoding evaluator input: %v", err))
+	}
+	var stdout, stderr bytes.Buffer
+	modCfg := wazero.NewModuleConfig().
+		WithName("").
+		WithStdin(bytes.NewReader(input)).
+		WithStdout(&stdout).
+		WithStderr(&stderr).
+		WithArgs(name, arg)
+	ctx, cancel := context.WithTimeout(context.Background(), customTimeout)
+	defer cancel()
+	mod, runErr := runtime.InstantiateModule(ctx, compiled, modCfg)
+	if mod != nil {
+		mod.Close(ctx)
+	}
+	exitCode := uint32(0)
+	if runErr != nil {
+		var exitErr *sys.ExitError
+		if !errors.As(runErr, &exitErr) {
+			return fail(fmt.Sprintf("running wasm plugin %q: %v", name, runErr))
+		}
+		exitCode = exitErr.ExitCode()
+	}
+
+	res := CheckResult{Passed: exitCode == 0}
+	if exitCode != 0 {
+		res.Message = fmt.Sprintf("wasm plugin %q failed with exit code %d", name, exitCode)
+	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		res = res.WithEvidence("stderr", msg)
+	}
+	out := strings.TrimSpace(stdout.String())
+	if out == "" {
+		return res
+	}
+	var verdict customVerdict
+	if err := json.Unmarshal([]byte(out), &verdict); err != nil {
+		res.Message = out
+		return res
+	}
+	if verdict.Passed != nil {
+		res.Passed = *verdict.Passed
+	}
+	res.Score = verdict.Score
+	if verdict.Message != "" {
+		res.Message = verdict.Message
+	}
+	for k, v := range verdict.Evidence {
+		res = res.WithEvidence(k, v)
+	}
+	return res
+}
@@ -0,0 +1,226 @@
+// Package gate evaluates recorded behavior against a policy file so CI
+// can block regressions in cost, latency and model usage.
+package gate
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+// Policy is the parsed .regrada/gates.yaml file. Zero-valued rules are
+// not enforced.
+type Policy struct {
+	// MaxTokenIncreasePct caps the total token growth of the latest
+	// session relative to the previous one, in percent.
+	MaxTokenIncreasePct float64 `yaml:"max_token_increase_pct,omitempty"`
+	// MaxCostUSD caps the estimated provider cost of the latest
+	// session.
+	MaxCostUSD float64 `yaml:"max_cost_usd,omitempty"`
+	// MaxInputTokensPerCall and MaxOutputTokensPerCall cap the prompt
+	// and completion tokens of any single call, catching prompt bloat
+	// and runaway context growth at the call that caused it.
+	MaxInputTokensPerCall  int `yaml:"max_input_tokens_per_call,omitempty"`
+	MaxOutputTokensPerCall int `yaml:"max_output_tokens_per_call,omitempty"`
+	// MaxInputTokensPerSession and MaxOutputTokensPerSession cap the
+	// session-wide totals.
+	MaxInputTokensPerSession  int `yaml:"max_input_tokens_per_session,omitempty"`
+	MaxOutputTokensPerSession int `yaml:"max_output_tokens_per_session,omitempty"`
+	// MaxP95LatencyMS caps the 95th-percentile call latency.
+	MaxP95LatencyMS float64 `yaml:"max_p95_latency_ms,omitempty"`
+	// MaxLatencyIncreasePct caps the growth of the p95 latency of the
+	// latest session relative to the previous one, in percent, so
+	// latency regressions block merges even under a generous absolute
+	// cap.
+	MaxLatencyIncreasePct float64 `yaml:"max_latency_increase_pct,omitempty"`
+	// ForbiddenModels lists model names that must not appear in the
+	// session, e.g. deprecated or unapproved models.
+	ForbiddenModels []string `yaml:"forbidden_models,omitempty"`
+	// ForbidNewTools fails when the session invokes a tool no earlier
+	// session has used.
+	ForbidNewTools bool `yaml:"forbid_new_tools,omitempty"`
+}
+
+// Violation is one failed policy rule.
+type Violation struct {
+	Rule    string
+	Message string
+}
+
+func (v Violation) String() string { return v.Rule + ": " + v.Message }
+
+// LoadPolicy reads and parses a gates.yaml policy file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading policy: %w", err)
+	}
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// Evaluate checks current against the policy. previous (the session
+// recorded before current) and earlier sessions feed the relative
+// rules; they may be nil or empty when there is no history.
+func (p *Policy) Evaluate(current *trace.Session, earlier []*trace.Session) []Violation {
+	var violations []Violation
+
+	if p.MaxTokenIncreasePct > 0 && len(earlier) > 0 {
+		cur, prev := totalTokens(current), totalTokens(earlier[0])
+		if prev > 0 {
+			increase := float64(cur-prev) / float64(prev) * 100
+			if increase > p.MaxTokenIncreasePct {
+				violations = append(violations, Violation{
+					Rule:    "max_token_increase_pct",
+					Message: fmt.Sprintf("tokens grew %.1f%% (%d -> %d), limit %.1f%%", increase, prev, cur, p.MaxTokenIncreasePct),
+				})
+			}
+		}
+	}
+
+	if p.MaxCostUSD > 0 {
+		if cost := EstimateCostUSD(current); cost > p.MaxCostUSD {
+			violations = append(violations, Violation{
+				Rule:    "max_cost_usd",
+				Message: fmt.Sprintf("estimated cost $%.4f exceeds limit $%.4f", cost, p.MaxCostUSD),
+			})
+		}
+	}
+
+	if p.MaxInputTokensPerCall > 0 || p.MaxOutputTokensPerCall > 0 {
+		for _, c := range current.Calls {
+			if p.MaxInputTokensPerCall > 0 && c.PromptTokens > p.MaxInputTokensPerCall {
+				violations = append(violations, Violation{
+					Rule:    "max_input_tokens_per_call",
+					Message: fmt.Sprintf("call #%d (%s) used %d input tokens, limit %d", c.Index, c.Model, c.PromptTokens, p.MaxInputTokensPerCall),
+				})
+			}
+			if p.MaxOutputTokensPerCall > 0 && c.CompletionTokens > p.MaxOutputTokensPerCall {
+				violations = append(violations, Violation{
+					Rule:    "max_output_tokens_per_call",
+					Message: fmt.Sprintf("call #%d (%s) produced %d output tokens, limit %d", c.Index, c.Model, c.CompletionTokens, p.MaxOutputTokensPerCall),
+				})
+			}
+		}
+	}
+
+	if p.MaxInputTokensPerSession > 0 || p.MaxOutputTokensPerSession > 0 {
+		in, out := inputOutputTokens(current)
+		if p.MaxInputTokensPerSession > 0 && in > p.MaxInputTokensPerSession {
+			violations = append(violations, Violation{
+				Rule:    "max_input_tokens_per_session",
+				Message: fmt.Sprintf("session used %d input tokens over %d calls, limit %d", in, len(current.Calls), p.MaxInputTokensPerSession),
+			})
+		}
+		if p.MaxOutputTokensPerSession > 0 && out > p.MaxOutputTokensPerSession {
+			violations = append(violations, Violation{
+				Rule:    "max_output_tokens_per_session",
+				Message: fmt.Sprintf("session produced %d output tokens over %d calls, limit %d", out, len(current.Calls), p.MaxOutputTokensPerSession),
+			})
+		}
+	}
+
+	if p.MaxP95LatencyMS > 0 {
+		if p95 := P95LatencyMS(current); p95 > p.MaxP95LatencyMS {
+			violations = append(violations, Violation{
+				Rule:    "max_p95_latency_ms",
+				Message: fmt.Sprintf("p95 latency %.0fms exceeds limit %.0fms", p95, p.MaxP95LatencyMS),
+			})
+		}
+	}
+
+	if p.MaxLatencyIncreasePct > 0 && len(earlier) > 0 {
+		cur, prev := P95LatencyMS(current), P95LatencyMS(earlier[0])
+		if prev > 0 {
+			increase := (cur - prev) / prev * 100
+			if increase > p.MaxLatencyIncreasePct {
+				violations = append(violations, Violation{
+					Rule:    "max_latency_increase_pct",
+					Message: fmt.Sprintf("p95 latency grew %.1f%% (%.0fms -> %.0fms), limit %.1f%%", increase, prev, cur, p.MaxLatencyIncreasePct),
+				})
+			}
+		}
+	}
+
+	if len(p.ForbiddenModels) > 0 {
+		forbidden := map[string]bool{}
+		for _, m := range p.ForbiddenModels {
+			forbidden[m] = true
+		}
+		seen := map[string]bool{}
+		for _, c := range current.Calls {
+			if forbidden[c.Model] && !seen[c.Model] {
+				seen[c.Model] = true
+				violations = append(violations, Violation{
+					Rule:    "forbidden_models",
+					Message: fmt.Sprintf("model %q is forbidden by policy", c.Model),
+				})
+			}
+		}
+	}
+
+	if p.ForbidNewTools && len(earlier) > 0 {
+		known := map[string]bool{}
+		for _, s := range earlier {
+			for _, c := range s.Calls {
+				for _, tool := range c.ToolCalls {
+					known[tool] = true
+				}
+			}
+		}
+		var newTools []string
+		seen := map[string]bool{}
+		for _, c := range current.Calls {
+			for _, tool := range c.ToolCalls {
+				if !known[tool] && !seen[tool] {
+					seen[tool] = true
+					newTools = append(newTools, tool)
+				}
+			}
+		}
+		if len(newTools) > 0 {
+			sort.Strings(newTools)
+			violations = append(violations, Violation{
+				Rule:    "forbid_new_tools",
+				Message: fmt.Sprintf("new tools not seen in any earlier session: %s", strings.Join(newTools, ", ")),
+			})
+		}
+	}
+
+	return violations
+}
+
+func totalTokens(s *trace.Session) int {
+	in, out := inputOutputTokens(s)
+	return in + out
+}
+
+func inputOutputTokens(s *trace.Session) (in, out int) {
+	for _, c := range s.Calls {
+		in += c.PromptTokens
+		out += c.CompletionTokens
+	}
+	return in, out
+}
+
+// P95LatencyMS returns the 95th-percentile call duration, 0 for an
+// empty session.
+func P95LatencyMS(s *trace.Session) float64 {
+	if len(s.Calls) == 0 {
+		return 0
+	}
+	durations := make([]float64, len(s.Calls))
+	for i, c := range s.Calls {
+		durations[i] = c.DurationMS
+	}
+	sort.Float64s(durations)
+	return trace.LatencyPercentile(durations, 95)
+}
@@ -0,0 +1,107 @@
+package gate
+
+import (
+	"strings"
+
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+// ModelPrice is the USD price of one model: per million prompt and
+// completion tokens, plus an optional flat cost per call (useful for
+// self-hosted models billed by invocation).
+type ModelPrice struct {
+	PromptPerMTok     float64
+	CompletionPerMTok float64
+	PerCall           float64
+}
+
+// pricePerMTok maps model name prefixes to USD prices per million
+// prompt/completion tokens. Matching is by longest prefix so dated
+// releases inherit their family price; unknown models cost zero and
+// are simply not gated on cost.
+var pricePerMTok = []struct {
+	prefix             string
+	prompt, completion float64
+}{
+	{"gpt-4o-mini", 0.15, 0.60},
+	{"gpt-4o", 2.50, 10.00},
+	{"gpt-4", 30.00, 60.00},
+	{"gpt-3.5", 0.50, 1.50},
+	{"claude-3-5-haiku", 0.80, 4.00},
+	{"claude-3-5-sonnet", 3.00, 15.00},
+	{"claude-3-opus", 15.00, 75.00},
+	{"claude-3-haiku", 0.25, 1.25},
+	{"mistral-large", 2.00, 6.00},
+	{"mistral-small", 0.20, 0.60},
+	{"text-embedding-3-small", 0.02, 0},
+	{"text-embedding-3-large", 0.13, 0},
+}
+
+// priceOverrides holds per-model prices from the project config. They
+// take precedence over the bundled table, so negotiated rates, Azure
+// pricing or self-hosted costs win.
+var priceOverrides = map[string]ModelPrice{}
+
+// SetPriceOverrides installs per-model price overrides, keyed by model
+// name prefix like the bundled table. It replaces any previous set.
+func SetPriceOverrides(prices map[string]ModelPrice) {
+	priceOverrides = map[string]ModelPrice{}
+	for prefix, p := range prices {
+		priceOverrides[prefix] = p
+	}
+}
+
+// priceFor resolves the price of a model by longest prefix match,
+// preferring configured overrides over the bundled table.
+func priceFor(model string) ModelPrice {
+	var best ModelPrice
+	length := -1
+	for prefix, p := range priceOverrides {
+		if strings.HasPrefix(model, prefix) && len(prefix) > length {
+			length = len(prefix)
+			best = p
+		}
+	}
+	if length >= 0 {
+		return best
+	}
+	for _, p := range pricePerMTok {
+		if strings.HasPrefix(model, p.prefix) && len(p.prefix) > length {
+			length = len(p.prefix)
+			best = ModelPrice{PromptPerMTok: p.prompt, CompletionPerMTok: p.completion}
+		}
+	}
+	return best
+}
+
+// EstimateCallCostUSD estimates the provider cost of one call from its
+// recorded token usage. Unknown models cost zero.
+func EstimateCallCostUSD(c *trace.Call) float64 {
+	p := priceFor(c.Model)
+	return float64(c.PromptTokens)/1e6*p.PromptPerMTok +
+		float64(c.CompletionTokens)/1e6*p.CompletionPerMTok +
+		p.PerCall
+}
+
+// EstimateCostUSD estimates the provider cost of a session from its
+// recorded token usage.
+func EstimateCostUSD(s *trace.Session) float64 {
+	var cost float64
+	for i := range s.Calls {
+		cost += EstimateCallCostUSD(&s.Calls[i])
+	}
+	return cost
+}
+
+// StampCosts writes the estimated cost onto every call in the session
+// and returns the total, so saved sessions carry dollar figures that
+// reports and profiles can sum without re-resolving prices.
+func StampCosts(s *trace.Session) float64 {
+	var total float64
+	for i := range s.Calls {
+		c := &s.Calls[i]
+		c.CostUSD = EstimateCallCostUSD(c)
+		total += c.CostUSD
+	}
+	return total
+}
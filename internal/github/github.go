@@ -0,0 +1,133 @@
+// Package github posts run summaries to GitHub pull requests using
+// the token and context that Actions provides.
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Marker identifies regrada's comment on a pull request so reruns
+// update it in place instead of stacking new comments.
+const Marker = "<!-- regrada-report -->"
+
+// Client talks to the GitHub REST API for one repository.
+type Client struct {
+	Token string
+	// Repo is the "owner/name" slug.
+	Repo string
+	// APIBase is the API root, overridable for GitHub Enterprise.
+	APIBase string
+
+	httpClient *http.Client
+}
+
+// NewClientFromEnv builds a client from the environment GitHub Actions
+// provides: GITHUB_TOKEN, GITHUB_REPOSITORY and (for Enterprise)
+// GITHUB_API_URL.
+func NewClientFromEnv() (*Client, error) {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("GITHUB_TOKEN is not set")
+	}
+	repo := os.Getenv("GITHUB_REPOSITORY")
+	if repo == "" {
+		return nil, fmt.Errorf("GITHUB_REPOSITORY is not set")
+	}
+	base := os.Getenv("GITHUB_API_URL")
+	if base == "" {
+		base = "https://api.github.com"
+	}
+	return &Client{
+		Token:      token,
+		Repo:       repo,
+		APIBase:    strings.TrimSuffix(base, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+var prRefPattern = regexp.MustCompile(`^refs/pull/(\d+)/`)
+
+// PRNumberFromEnv extracts the pull request number from the Actions
+// context: GITHUB_REF ("refs/pull/123/merge") or REGRADA_PR_NUMBER for
+// CI systems that do not set it.
+func PRNumberFromEnv() (int, error) {
+	if v := os.Getenv("REGRADA_PR_NUMBER"); v != "" {
+		return strconv.Atoi(v)
+	}
+	if m := prRefPattern.FindStringSubmatch(os.Getenv("GITHUB_REF")); m != nil {
+		return strconv.Atoi(m[1])
+	}
+	return 0, fmt.Errorf("not running on a pull request (GITHUB_REF=%q)", os.Getenv("GITHUB_REF"))
+}
+
+// comment is the slice of the API's comment object we need.
+type comment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+}
+
+// UpsertPRComment posts body as a comment on the pull request, or
+// updates regrada's existing comment (found by Marker) in place.
+func (c *Client) UpsertPRComment(pr int, body string) error {
+	existing, err := c.findComment(pr)
+	if err != nil {
+		return err
+	}
+	payload, _ := json.Marshal(map[string]string{"body": body})
+	if existing != 0 {
+		return c.do(http.MethodPatch,
+			fmt.Sprintf("%s/repos/%s/issues/comments/%d", c.APIBase, c.Repo, existing), payload, nil)
+	}
+	return c.do(http.MethodPost,
+		fmt.Sprintf("%s/repos/%s/issues/%d/comments", c.APIBase, c.Repo, pr), payload, nil)
+}
+
+// findComment returns the id of regrada's existing comment on the pull
+// request, or zero.
+func (c *Client) findComment(pr int) (int64, error) {
+	var comments []comment
+	url := fmt.Sprintf("%s/repos/%s/issues/%d/comments?per_page=100", c.APIBase, c.Repo, pr)
+	if err := c.do(http.MethodGet, url, nil, &comments); err != nil {
+		return 0, err
+	}
+	for _, cm := range comments {
+		if strings.Contains(cm.Body, Marker) {
+			return cm.ID, nil
+		}
+	}
+	return 0, nil
+}
+
+// do performs one API request, decoding the response into out when
+// non-nil.
+func (c *Client) do(method, url string, body []byte, out any) error {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("github: %s %s: %s", method, url, resp.Status)
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Package gitlab posts run summaries to GitLab merge requests using
+// the variables GitLab CI provides, mirroring the github package.
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Marker identifies regrada's note on a merge request so reruns update
+// it in place instead of stacking new notes.
+const Marker = "<!-- regrada-report -->"
+
+// InCI reports whether the process is running inside GitLab CI.
+func InCI() bool { return os.Getenv("GITLAB_CI") == "true" }
+
+// Client talks to the GitLab REST API for one project.
+type Client struct {
+	Token string
+	// APIBase is the v4 API root (CI_API_V4_URL).
+	APIBase string
+	// Project is the project id or URL-encoded path.
+	Project string
+
+	httpClient *http.Client
+}
+
+// NewClientFromEnv builds a client from the environment GitLab CI
+// provides: GITLAB_TOKEN, CI_API_V4_URL and CI_PROJECT_ID.
+func NewClientFromEnv() (*Client, error) {
+	token := os.Getenv("GITLAB_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("GITLAB_TOKEN is not set")
+	}
+	base := os.Getenv("CI_API_V4_URL")
+	if base == "" {
+		return nil, fmt.Errorf("CI_API_V4_URL is not set")
+	}
+	project := os.Getenv("CI_PROJECT_ID")
+	if project == "" {
+		return nil, fmt.Errorf("CI_PROJECT_ID is not set")
+	}
+	return &Client{
+		Token:      token,
+		APIBase:    strings.TrimSuffix(base, "/"),
+		Project:    url.PathEscape(project),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// MRIIDFromEnv returns the merge request iid from the CI context:
+// CI_MERGE_REQUEST_IID, or REGRADA_MR_IID as an override.
+func MRIIDFromEnv() (int, error) {
+	if v := os.Getenv("REGRADA_MR_IID"); v != "" {
+		return strconv.Atoi(v)
+	}
+	if v := os.Getenv("CI_MERGE_REQUEST_IID"); v != "" {
+		return strconv.Atoi(v)
+	}
+	return 0, fmt.Errorf("not running on a merge request (CI_MERGE_REQUEST_IID is not set)")
+}
+
+// note is the slice of the API's note object we need.
+type note struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+}
+
+// UpsertMRNote posts body as a note on the merge request, or updates
+// regrada's existing note (found by Marker) in place.
+func (c *Client) UpsertMRNote(iid int, body string) error {
+	existing, err := c.findNote(iid)
+	if err != nil {
+		return err
+	}
+	payload, _ := json.Marshal(map[string]string{"body": body})
+	if existing != 0 {
+		return c.do(http.MethodPut,
+			fmt.Sprintf("%s/projects/%s/merge_requests/%d/notes/%d", c.APIBase, c.Project, iid, existing), payload, nil)
+	}
+	return c.do(http.MethodPost,
+		fmt.Sprintf("%s/projects/%s/merge_requests/%d/notes", c.APIBase, c.Project, iid), payload, nil)
+}
+
+// findNote returns the id of regrada's existing note on the merge
+// request, or zero.
+func (c *Client) findNote(iid int) (int64, error) {
+	var notes []note
+	url := fmt.Sprintf("%s/projects/%s/merge_requests/%d/notes?per_page=100", c.APIBase, c.Project, iid)
+	if err := c.do(http.MethodGet, url, nil, &notes); err != nil {
+		return 0, err
+	}
+	for _, n := range notes {
+		if strings.Contains(n.Body, Marker) {
+			return n.ID, nil
+		}
+	}
+	return 0, nil
+}
+
+// do performs one API request, decoding the response into out when
+// non-nil.
+func (c *Client) do(method, url string, body []byte, out any) error {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("gitlab: %s %s: %s", method, url, resp.Status)
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
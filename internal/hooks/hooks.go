@@ -0,0 +1,91 @@
+// Package hooks invokes configured executables and in-process
+// callbacks on lifecycle events, so custom integrations can react to
+// runs without forking the tool.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Event names one lifecycle moment hooks can subscribe to.
+type Event string
+
+const (
+	EventRunStarted         Event = "run_started"
+	EventTestFinished       Event = "test_finished"
+	EventRegressionDetected Event = "regression_detected"
+	EventBaselineSaved      Event = "baseline_saved"
+)
+
+// Callback is an in-process hook, registered by Go programs embedding
+// regrada (see the public hooks package).
+type Callback func(event Event, payload any)
+
+var callbacks []Callback
+
+// RegisterCallback adds an in-process hook fired on every event.
+func RegisterCallback(fn Callback) {
+	callbacks = append(callbacks, fn)
+}
+
+// hookTimeout bounds one hook executable so a hung integration cannot
+// stall the run.
+const hookTimeout = 30 * time.Second
+
+// Hooks fires events into the commands configured for them.
+type Hooks struct {
+	// Commands maps event names to the executables run for them.
+	Commands map[string][]string
+	// Dir is the working directory for hook commands (project root).
+	Dir string
+}
+
+// New returns Hooks over the config's event-to-command map.
+func New(commands map[string][]string, dir string) *Hooks {
+	return &Hooks{Commands: commands, Dir: dir}
+}
+
+// Fire delivers the event: in-process callbacks first, then each
+// configured executable with the JSON payload on stdin and the event
+// name in REGRADA_EVENT. Hook failures are reported on stderr but
+// never fail the run.
+func (h *Hooks) Fire(event Event, payload any) {
+	for _, fn := range callbacks {
+		fn(event, payload)
+	}
+	if h == nil {
+		return
+	}
+	cmds := h.Commands[string(event)]
+	if len(cmds) == 0 {
+		return
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "regrada: hook payload for %s: %v\n", event, err)
+		return
+	}
+	for _, line := range cmds {
+		parts := strings.Fields(line)
+		if len(parts) == 0 {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+		cmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
+		cmd.Dir = h.Dir
+		cmd.Env = append(os.Environ(), "REGRADA_EVENT="+string(event))
+		cmd.Stdin = bytes.NewReader(data)
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "regrada: hook %q on %s: %v\n", line, event, err)
+		}
+		cancel()
+	}
+}
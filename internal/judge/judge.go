@@ -0,0 +1,108 @@
+// Package judge calls the configured judge model for LLM-assisted
+// conveniences such as change summaries. Only OpenAI-compatible chat
+// APIs are spoken; OPENAI_BASE_URL redirects the calls to a gateway or
+// a local model.
+package judge
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultModel is the judge used when the config names none.
+const DefaultModel = "gpt-4o-mini"
+
+// judgeTimeout bounds one judge call.
+const judgeTimeout = 60 * time.Second
+
+// Change is one before/after response pair the judge is asked about.
+type Change struct {
+	Test   string
+	Before string
+	After  string
+}
+
+// Summarize asks the judge model for a short paragraph describing how
+// behavior changed across the given tests. Responses are redacted
+// before leaving the machine: emails, API keys and long digit runs are
+// masked.
+func Summarize(model string, changes []Change) (string, error) {
+	if model == "" {
+		model = DefaultModel
+	}
+	key := os.Getenv("OPENAI_API_KEY")
+	if key == "" {
+		return "", fmt.Errorf("OPENAI_API_KEY not set; the judge model needs it")
+	}
+	base := strings.TrimSuffix(os.Getenv("OPENAI_BASE_URL"), "/")
+	if base == "" {
+		base = "https://api.openai.com/v1"
+	}
+
+	var b strings.Builder
+	for _, ch := range changes {
+		fmt.Fprintf(&b, "Test: %s\nBefore:\n%s\nAfter:\n%s\n\n", ch.Test, Redact(ch.Before), Redact(ch.After))
+	}
+	payload := map[string]any{
+		"model": model,
+		"messages": []map[string]string{
+			{"role": "system", "content": "You review LLM application test output. Summarize how the responses changed between the two versions in one short paragraph for a pull request comment: what changed in substance, tone or format, and whether it looks intentional. Do not quote the responses at length."},
+			{"role": "user", "content": b.String()},
+		},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest(http.MethodPost, base+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+key)
+	client := &http.Client{Timeout: judgeTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("calling judge model: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("judge model returned %s", resp.Status)
+	}
+	var out struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("parsing judge response: %w", err)
+	}
+	if len(out.Choices) == 0 {
+		return "", fmt.Errorf("judge model returned no choices")
+	}
+	return strings.TrimSpace(out.Choices[0].Message.Content), nil
+}
+
+// Redaction patterns: enough to keep obvious secrets and PII out of a
+// third-party model, not a DLP system.
+var redactions = []*regexp.Regexp{
+	regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+	regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{10,}\b`),
+	regexp.MustCompile(`\b\d[\d -]{10,}\d\b`),
+}
+
+// Redact masks emails, API keys and long digit runs in s.
+func Redact(s string) string {
+	for _, re := range redactions {
+		s = re.ReplaceAllString(s, "[redacted]")
+	}
+	return s
+}
@@ -0,0 +1,56 @@
+// Package language provides a cheap stopword-based language detector,
+// good enough to catch an agent switching reply language, not a
+// general-purpose classifier.
+package language
+
+import "strings"
+
+// stopwords maps ISO 639-1 codes to high-frequency function words.
+// Words shared between languages are fine: detection goes to the
+// highest total, and ties resolve to unknown.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "is", "are", "you", "your", "with", "for", "this", "that", "have", "will"},
+	"es": {"el", "la", "los", "las", "es", "de", "que", "por", "con", "para", "una", "usted"},
+	"fr": {"le", "la", "les", "est", "de", "que", "pour", "avec", "vous", "une", "dans", "nous"},
+	"de": {"der", "die", "das", "ist", "und", "nicht", "mit", "für", "sie", "ein", "eine", "wir"},
+	"pt": {"o", "a", "os", "as", "é", "de", "que", "por", "com", "para", "uma", "você"},
+	"it": {"il", "la", "le", "è", "di", "che", "per", "con", "una", "questo", "sono", "noi"},
+}
+
+// Detect returns the ISO 639-1 code of the text's probable language,
+// or "" when there is not enough signal to decide.
+func Detect(text string) string {
+	if text == "" {
+		return ""
+	}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(('a' <= r && r <= 'z') || r == 'ä' || r == 'ö' || r == 'ü' || r == 'ß' ||
+			r == 'é' || r == 'è' || r == 'ê' || r == 'à' || r == 'ç' || r == 'í' ||
+			r == 'ó' || r == 'ú' || r == 'ñ' || r == 'ã' || r == 'õ' || r == 'â')
+	})
+	counts := map[string]int{}
+	for _, w := range words {
+		for code, list := range stopwords {
+			for _, sw := range list {
+				if w == sw {
+					counts[code]++
+					break
+				}
+			}
+		}
+	}
+	best, bestCount, tied := "", 0, false
+	for code, n := range counts {
+		switch {
+		case n > bestCount:
+			best, bestCount, tied = code, n, false
+		case n == bestCount && n > 0:
+			tied = true
+		}
+	}
+	// Demand a minimum of signal: one stray stopword is noise.
+	if bestCount < 2 || tied {
+		return ""
+	}
+	return best
+}
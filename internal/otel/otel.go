@@ -0,0 +1,127 @@
+// Package otel exports recorded trace sessions to an OpenTelemetry
+// collector over OTLP/HTTP, so regrada captures show up alongside the
+// application's own traces in Jaeger, Tempo or Datadog. The payload is
+// built by hand: pulling in the OTel SDK to emit a handful of spans
+// would dwarf the rest of the binary.
+package otel
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+// exportTimeout bounds one export so a hung collector cannot stall the
+// command.
+const exportTimeout = 10 * time.Second
+
+// Export sends every call in the session to the collector at endpoint
+// (an OTLP/HTTP base URL such as http://localhost:4318) as one trace
+// with one client span per call, attributed with the gen_ai semantic
+// conventions. It returns the number of spans exported.
+func Export(endpoint string, s *trace.Session) (int, error) {
+	if len(s.Calls) == 0 {
+		return 0, nil
+	}
+	// One trace per session, derived from the session ID so repeated
+	// exports of the same session land on the same trace.
+	sum := md5.Sum([]byte(s.ID))
+	traceID := hex.EncodeToString(sum[:])
+
+	spans := make([]map[string]any, 0, len(s.Calls))
+	for i := range s.Calls {
+		spans = append(spans, span(traceID, &s.Calls[i]))
+	}
+	payload := map[string]any{
+		"resourceSpans": []map[string]any{{
+			"resource": map[string]any{"attributes": []map[string]any{
+				strAttr("service.name", "regrada"),
+				strAttr("regrada.session_id", s.ID),
+			}},
+			"scopeSpans": []map[string]any{{
+				"scope": map[string]any{"name": "regrada"},
+				"spans": spans,
+			}},
+		}},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	url := strings.TrimSuffix(endpoint, "/")
+	if !strings.HasSuffix(url, "/v1/traces") {
+		url += "/v1/traces"
+	}
+	client := &http.Client{Timeout: exportTimeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("exporting to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return 0, fmt.Errorf("collector returned %s", resp.Status)
+	}
+	return len(spans), nil
+}
+
+// span shapes one recorded call into an OTLP span.
+func span(traceID string, c *trace.Call) map[string]any {
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	start := c.StartedAt.UnixNano()
+	end := c.StartedAt.Add(time.Duration(c.DurationMS * float64(time.Millisecond))).UnixNano()
+
+	name := "chat " + c.Model
+	if c.IsEmbedding() {
+		name = "embeddings " + c.Model
+	}
+	attrs := []map[string]any{
+		strAttr("gen_ai.system", c.Provider),
+		strAttr("gen_ai.request.model", c.Model),
+		intAttr("gen_ai.usage.input_tokens", int64(c.PromptTokens)),
+		intAttr("gen_ai.usage.output_tokens", int64(c.CompletionTokens)),
+		strAttr("http.request.method", c.Method),
+		strAttr("url.full", c.URL),
+		intAttr("http.response.status_code", int64(c.Status)),
+	}
+	if c.Service != "" {
+		attrs = append(attrs, strAttr("regrada.service", c.Service))
+	}
+	if c.Fault != "" {
+		attrs = append(attrs, strAttr("regrada.fault", c.Fault))
+	}
+
+	status := map[string]any{}
+	if c.Status >= 400 {
+		status["code"] = 2 // STATUS_CODE_ERROR
+	}
+	return map[string]any{
+		"traceId": traceID,
+		"spanId":  hex.EncodeToString(id[:]),
+		"name":    name,
+		// SPAN_KIND_CLIENT: the traced app calling out to a provider.
+		"kind":              3,
+		"startTimeUnixNano": strconv.FormatInt(start, 10),
+		"endTimeUnixNano":   strconv.FormatInt(end, 10),
+		"attributes":        attrs,
+		"status":            status,
+	}
+}
+
+func strAttr(key, value string) map[string]any {
+	return map[string]any{"key": key, "value": map[string]any{"stringValue": value}}
+}
+
+func intAttr(key string, value int64) map[string]any {
+	return map[string]any{"key": key, "value": map[string]any{"intValue": strconv.FormatInt(value, 10)}}
+}
@@ -0,0 +1,170 @@
+package providers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+func init() {
+	Register(&anthropic{})
+}
+
+// anthropic handles the Anthropic messages wire format.
+type anthropic struct{}
+
+func (*anthropic) Name() string { return "anthropic" }
+
+func (*anthropic) Match(host string) bool {
+	return host == "api.anthropic.com" || strings.HasSuffix(host, ".anthropic.com")
+}
+
+func (*anthropic) APIKeyEnv() string { return "ANTHROPIC_API_KEY" }
+
+func (*anthropic) HealthURL() string { return "https://api.anthropic.com/v1/models" }
+
+func (*anthropic) Authenticate(req *http.Request, key string) {
+	req.Header.Set("x-api-key", key)
+	if req.Header.Get("anthropic-version") == "" {
+		req.Header.Set("anthropic-version", "2023-06-01")
+	}
+}
+
+func (*anthropic) ParseRequest(body []byte) (*RequestInfo, error) {
+	var req struct {
+		Model string `json:"model"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+	return &RequestInfo{Model: req.Model}, nil
+}
+
+// ReassembleStream folds messages-API stream events back into a
+// complete non-streaming response, including tool_use input deltas and
+// the usage totals from message_start/message_delta.
+func (*anthropic) ReassembleStream(raw []byte) ([]byte, error) {
+	type block struct {
+		Type string
+		Name string
+		Text strings.Builder
+		JSON strings.Builder
+	}
+	var (
+		model        string
+		stopReason   string
+		inputTokens  int
+		outputTokens int
+		blocks       []*block
+	)
+	for _, payload := range sseEvents(raw) {
+		var ev struct {
+			Type    string `json:"type"`
+			Message struct {
+				Model string `json:"model"`
+				Usage struct {
+					InputTokens int `json:"input_tokens"`
+				} `json:"usage"`
+			} `json:"message"`
+			Index        int `json:"index"`
+			ContentBlock struct {
+				Type string `json:"type"`
+				Name string `json:"name"`
+			} `json:"content_block"`
+			Delta struct {
+				Type        string `json:"type"`
+				Text        string `json:"text"`
+				PartialJSON string `json:"partial_json"`
+				StopReason  string `json:"stop_reason"`
+			} `json:"delta"`
+			Usage struct {
+				OutputTokens int `json:"output_tokens"`
+			} `json:"usage"`
+		}
+		if err := json.Unmarshal([]byte(payload), &ev); err != nil {
+			continue
+		}
+		switch ev.Type {
+		case "message_start":
+			model = ev.Message.Model
+			inputTokens = ev.Message.Usage.InputTokens
+		case "content_block_start":
+			for ev.Index >= len(blocks) {
+				blocks = append(blocks, &block{})
+			}
+			blocks[ev.Index].Type = ev.ContentBlock.Type
+			blocks[ev.Index].Name = ev.ContentBlock.Name
+		case "content_block_delta":
+			if ev.Index < len(blocks) {
+				blocks[ev.Index].Text.WriteString(ev.Delta.Text)
+				blocks[ev.Index].JSON.WriteString(ev.Delta.PartialJSON)
+			}
+		case "message_delta":
+			if ev.Delta.StopReason != "" {
+				stopReason = ev.Delta.StopReason
+			}
+			if ev.Usage.OutputTokens > 0 {
+				outputTokens = ev.Usage.OutputTokens
+			}
+		}
+	}
+
+	var content []map[string]any
+	for _, b := range blocks {
+		switch b.Type {
+		case "tool_use":
+			input := json.RawMessage("{}")
+			if s := b.JSON.String(); s != "" && json.Valid([]byte(s)) {
+				input = json.RawMessage(s)
+			}
+			content = append(content, map[string]any{"type": "tool_use", "name": b.Name, "input": input})
+		default:
+			content = append(content, map[string]any{"type": "text", "text": b.Text.String()})
+		}
+	}
+	return json.Marshal(map[string]any{
+		"type":        "message",
+		"role":        "assistant",
+		"model":       model,
+		"content":     content,
+		"stop_reason": stopReason,
+		"usage": map[string]int{
+			"input_tokens":  inputTokens,
+			"output_tokens": outputTokens,
+		},
+	})
+}
+
+func (*anthropic) ParseResponse(body []byte) (*ResponseInfo, error) {
+	var resp struct {
+		Model   string `json:"model"`
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+			Name string `json:"name"`
+		} `json:"content"`
+		Usage struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	info := &ResponseInfo{
+		Model:            resp.Model,
+		PromptTokens:     resp.Usage.InputTokens,
+		CompletionTokens: resp.Usage.OutputTokens,
+	}
+	for _, block := range resp.Content {
+		switch block.Type {
+		case "text":
+			info.Text += block.Text
+		case "tool_use":
+			if block.Name != "" {
+				info.ToolCalls = append(info.ToolCalls, block.Name)
+			}
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,56 @@
+package providers
+
+import (
+	"net/http"
+	"os"
+	"strings"
+)
+
+func init() {
+	Register(&azureOpenAI{})
+}
+
+// azureOpenAI handles Azure OpenAI deployments. The wire format is the
+// OpenAI chat completions shape (embedded), but the endpoint is
+// per-resource, the model is named by the deployment path segment
+// rather than a body field, and authentication uses the api-key
+// header.
+type azureOpenAI struct{ openAI }
+
+func (*azureOpenAI) Name() string { return "azure-openai" }
+
+func (*azureOpenAI) Match(host string) bool {
+	return strings.HasSuffix(host, ".openai.azure.com")
+}
+
+func (*azureOpenAI) APIKeyEnv() string { return "AZURE_OPENAI_API_KEY" }
+
+// HealthURL targets the resource endpoint from the environment; there
+// is no fixed Azure host to ping.
+func (*azureOpenAI) HealthURL() string {
+	ep := strings.TrimSuffix(os.Getenv("AZURE_OPENAI_ENDPOINT"), "/")
+	if ep == "" {
+		return ""
+	}
+	return ep + "/openai/models?api-version=2024-02-01"
+}
+
+func (*azureOpenAI) Authenticate(req *http.Request, key string) {
+	req.Header.Set("api-key", key)
+}
+
+// AzureDeployment extracts the deployment name from an Azure OpenAI
+// request path like /openai/deployments/gpt-4o-prod/chat/completions,
+// or "" when the path has no deployment segment.
+func AzureDeployment(path string) string {
+	const marker = "/deployments/"
+	i := strings.Index(path, marker)
+	if i < 0 {
+		return ""
+	}
+	rest := strings.TrimPrefix(path[i+len(marker):], "/")
+	if j := strings.IndexByte(rest, '/'); j >= 0 {
+		rest = rest[:j]
+	}
+	return rest
+}
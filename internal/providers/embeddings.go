@@ -0,0 +1,32 @@
+package providers
+
+import "encoding/json"
+
+// parseEmbeddings recognizes the OpenAI-style embeddings response
+// (also used by Azure, Mistral and Ollama-compatible endpoints):
+// a list object whose data entries carry embedding vectors. It returns
+// the extracted info and whether the body was an embeddings response.
+func parseEmbeddings(body []byte) (*ResponseInfo, bool) {
+	var resp struct {
+		Object string `json:"object"`
+		Model  string `json:"model"`
+		Data   []struct {
+			Embedding []float64 `json:"embedding"`
+		} `json:"data"`
+		Usage struct {
+			PromptTokens int `json:"prompt_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, false
+	}
+	if resp.Object != "list" || len(resp.Data) == 0 || len(resp.Data[0].Embedding) == 0 {
+		return nil, false
+	}
+	return &ResponseInfo{
+		Model:           resp.Model,
+		PromptTokens:    resp.Usage.PromptTokens,
+		EmbeddingInputs: len(resp.Data),
+		EmbeddingDims:   len(resp.Data[0].Embedding),
+	}, true
+}
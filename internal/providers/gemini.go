@@ -0,0 +1,74 @@
+package providers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+func init() {
+	Register(&gemini{})
+}
+
+// gemini handles the Google Gemini generateContent wire format, both
+// the generativelanguage.googleapis.com API and Vertex-style paths.
+type gemini struct{}
+
+func (*gemini) Name() string { return "gemini" }
+
+func (*gemini) Match(host string) bool {
+	return host == "generativelanguage.googleapis.com" ||
+		strings.HasSuffix(host, "-aiplatform.googleapis.com") ||
+		host == "aiplatform.googleapis.com"
+}
+
+func (*gemini) APIKeyEnv() string { return "GEMINI_API_KEY" }
+
+func (*gemini) HealthURL() string { return "https://generativelanguage.googleapis.com/v1beta/models" }
+
+func (*gemini) Authenticate(req *http.Request, key string) {
+	req.Header.Set("x-goog-api-key", key)
+}
+
+func (*gemini) ParseRequest(body []byte) (*RequestInfo, error) {
+	// Gemini carries the model in the URL path, not the body; the
+	// response's modelVersion fills it in instead.
+	return &RequestInfo{}, nil
+}
+
+func (*gemini) ParseResponse(body []byte) (*ResponseInfo, error) {
+	var resp struct {
+		ModelVersion string `json:"modelVersion"`
+		Candidates   []struct {
+			Content struct {
+				Parts []struct {
+					Text         string `json:"text"`
+					FunctionCall struct {
+						Name string `json:"name"`
+					} `json:"functionCall"`
+				} `json:"parts"`
+			} `json:"content"`
+		} `json:"candidates"`
+		UsageMetadata struct {
+			PromptTokenCount     int `json:"promptTokenCount"`
+			CandidatesTokenCount int `json:"candidatesTokenCount"`
+		} `json:"usageMetadata"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	info := &ResponseInfo{
+		Model:            resp.ModelVersion,
+		PromptTokens:     resp.UsageMetadata.PromptTokenCount,
+		CompletionTokens: resp.UsageMetadata.CandidatesTokenCount,
+	}
+	for _, c := range resp.Candidates {
+		for _, part := range c.Content.Parts {
+			info.Text += part.Text
+			if part.FunctionCall.Name != "" {
+				info.ToolCalls = append(info.ToolCalls, part.FunctionCall.Name)
+			}
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,76 @@
+package providers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+func init() {
+	Register(&mistral{})
+}
+
+// mistral handles the Mistral AI chat completions wire format, which
+// tracks the OpenAI shape closely but has its own host and key.
+type mistral struct{}
+
+func (*mistral) Name() string { return "mistral" }
+
+func (*mistral) Match(host string) bool {
+	return host == "api.mistral.ai" || strings.HasSuffix(host, ".mistral.ai")
+}
+
+func (*mistral) APIKeyEnv() string { return "MISTRAL_API_KEY" }
+
+func (*mistral) HealthURL() string { return "https://api.mistral.ai/v1/models" }
+
+func (*mistral) Authenticate(req *http.Request, key string) {
+	req.Header.Set("Authorization", "Bearer "+key)
+}
+
+func (*mistral) ParseRequest(body []byte) (*RequestInfo, error) {
+	var req struct {
+		Model string `json:"model"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+	return &RequestInfo{Model: req.Model}, nil
+}
+
+func (*mistral) ParseResponse(body []byte) (*ResponseInfo, error) {
+	var resp struct {
+		Model   string `json:"model"`
+		Choices []struct {
+			Message struct {
+				Content   string `json:"content"`
+				ToolCalls []struct {
+					Function struct {
+						Name string `json:"name"`
+					} `json:"function"`
+				} `json:"tool_calls"`
+			} `json:"message"`
+		} `json:"choices"`
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	info := &ResponseInfo{
+		Model:            resp.Model,
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+	}
+	for _, c := range resp.Choices {
+		info.Text += c.Message.Content
+		for _, tc := range c.Message.ToolCalls {
+			if tc.Function.Name != "" {
+				info.ToolCalls = append(info.ToolCalls, tc.Function.Name)
+			}
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,70 @@
+package providers
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func init() {
+	Register(&ollama{})
+}
+
+// ollama handles the Ollama local-model API (/api/chat and
+// /api/generate). There is no API key; the server runs on localhost.
+type ollama struct{}
+
+func (*ollama) Name() string { return "ollama" }
+
+func (*ollama) Match(host string) bool {
+	return host == "localhost" || host == "127.0.0.1"
+}
+
+func (*ollama) APIKeyEnv() string { return "" }
+
+func (*ollama) HealthURL() string { return "http://localhost:11434/api/tags" }
+
+func (*ollama) Authenticate(req *http.Request, key string) {}
+
+func (*ollama) ParseRequest(body []byte) (*RequestInfo, error) {
+	var req struct {
+		Model string `json:"model"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+	return &RequestInfo{Model: req.Model}, nil
+}
+
+func (*ollama) ParseResponse(body []byte) (*ResponseInfo, error) {
+	// /api/chat wraps output in message; /api/generate uses a flat
+	// response field. eval_count is the completion token count.
+	var resp struct {
+		Model   string `json:"model"`
+		Message struct {
+			Content   string `json:"content"`
+			ToolCalls []struct {
+				Function struct {
+					Name string `json:"name"`
+				} `json:"function"`
+			} `json:"tool_calls"`
+		} `json:"message"`
+		Response        string `json:"response"`
+		PromptEvalCount int    `json:"prompt_eval_count"`
+		EvalCount       int    `json:"eval_count"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	info := &ResponseInfo{
+		Model:            resp.Model,
+		Text:             resp.Message.Content + resp.Response,
+		PromptTokens:     resp.PromptEvalCount,
+		CompletionTokens: resp.EvalCount,
+	}
+	for _, tc := range resp.Message.ToolCalls {
+		if tc.Function.Name != "" {
+			info.ToolCalls = append(info.ToolCalls, tc.Function.Name)
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,174 @@
+package providers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+func init() {
+	Register(&openAI{})
+}
+
+// openAI handles the OpenAI chat completions wire format.
+type openAI struct{}
+
+func (*openAI) Name() string { return "openai" }
+
+func (*openAI) Match(host string) bool {
+	return host == "api.openai.com" || strings.HasSuffix(host, ".openai.com")
+}
+
+func (*openAI) APIKeyEnv() string { return "OPENAI_API_KEY" }
+
+func (*openAI) HealthURL() string { return "https://api.openai.com/v1/models" }
+
+func (*openAI) Authenticate(req *http.Request, key string) {
+	req.Header.Set("Authorization", "Bearer "+key)
+}
+
+func (*openAI) ParseRequest(body []byte) (*RequestInfo, error) {
+	var req struct {
+		Model string `json:"model"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+	return &RequestInfo{Model: req.Model}, nil
+}
+
+// ReassembleStream folds chat completion chunk deltas back into a
+// complete non-streaming response, including tool_call deltas and the
+// usage block from the final chunk.
+func (*openAI) ReassembleStream(raw []byte) ([]byte, error) {
+	type toolCall struct {
+		Index int
+		ID    string
+		Name  string
+		Args  strings.Builder
+	}
+	var (
+		model     string
+		content   strings.Builder
+		finish    string
+		toolCalls []*toolCall
+		usage     json.RawMessage
+	)
+	for _, payload := range sseEvents(raw) {
+		var chunk struct {
+			Model   string `json:"model"`
+			Choices []struct {
+				Delta struct {
+					Content   string `json:"content"`
+					ToolCalls []struct {
+						Index    int    `json:"index"`
+						ID       string `json:"id"`
+						Function struct {
+							Name      string `json:"name"`
+							Arguments string `json:"arguments"`
+						} `json:"function"`
+					} `json:"tool_calls"`
+				} `json:"delta"`
+				FinishReason *string `json:"finish_reason"`
+			} `json:"choices"`
+			Usage json.RawMessage `json:"usage"`
+		}
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			continue
+		}
+		if chunk.Model != "" {
+			model = chunk.Model
+		}
+		if len(chunk.Usage) > 0 && string(chunk.Usage) != "null" {
+			usage = chunk.Usage
+		}
+		for _, c := range chunk.Choices {
+			content.WriteString(c.Delta.Content)
+			if c.FinishReason != nil {
+				finish = *c.FinishReason
+			}
+			for _, tc := range c.Delta.ToolCalls {
+				for tc.Index >= len(toolCalls) {
+					toolCalls = append(toolCalls, &toolCall{Index: len(toolCalls)})
+				}
+				cur := toolCalls[tc.Index]
+				if tc.ID != "" {
+					cur.ID = tc.ID
+				}
+				if tc.Function.Name != "" {
+					cur.Name = tc.Function.Name
+				}
+				cur.Args.WriteString(tc.Function.Arguments)
+			}
+		}
+	}
+
+	message := map[string]any{"role": "assistant", "content": content.String()}
+	if len(toolCalls) > 0 {
+		var tcs []map[string]any
+		for _, tc := range toolCalls {
+			tcs = append(tcs, map[string]any{
+				"id":   tc.ID,
+				"type": "function",
+				"function": map[string]any{
+					"name":      tc.Name,
+					"arguments": tc.Args.String(),
+				},
+			})
+		}
+		message["tool_calls"] = tcs
+	}
+	out := map[string]any{
+		"object": "chat.completion",
+		"model":  model,
+		"choices": []map[string]any{{
+			"index":         0,
+			"message":       message,
+			"finish_reason": finish,
+		}},
+	}
+	if usage != nil {
+		out["usage"] = usage
+	}
+	return json.Marshal(out)
+}
+
+func (*openAI) ParseResponse(body []byte) (*ResponseInfo, error) {
+	if info, ok := parseEmbeddings(body); ok {
+		return info, nil
+	}
+	var resp struct {
+		Model   string `json:"model"`
+		Choices []struct {
+			Message struct {
+				Content   string `json:"content"`
+				ToolCalls []struct {
+					Function struct {
+						Name string `json:"name"`
+					} `json:"function"`
+				} `json:"tool_calls"`
+			} `json:"message"`
+		} `json:"choices"`
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	info := &ResponseInfo{
+		Model:            resp.Model,
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+	}
+	for _, c := range resp.Choices {
+		info.Text += c.Message.Content
+		for _, tc := range c.Message.ToolCalls {
+			if tc.Function.Name != "" {
+				info.ToolCalls = append(info.ToolCalls, tc.Function.Name)
+			}
+		}
+	}
+	return info, nil
+}
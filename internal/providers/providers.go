@@ -0,0 +1,88 @@
+// Package providers knows how to recognize, authenticate against and
+// parse the wire formats of the model providers regrada traces.
+package providers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RequestInfo is what a provider can extract from a request body.
+type RequestInfo struct {
+	Model string
+}
+
+// ResponseInfo is what a provider can extract from a response body.
+type ResponseInfo struct {
+	Model            string
+	Text             string
+	ToolCalls        []string
+	PromptTokens     int
+	CompletionTokens int
+	// EmbeddingInputs and EmbeddingDims describe an embeddings
+	// response: how many vectors came back and their dimensionality.
+	// Both are zero for completion calls.
+	EmbeddingInputs int
+	EmbeddingDims   int
+}
+
+// Provider describes one model provider's wire format.
+type Provider interface {
+	// Name is the short provider name ("openai").
+	Name() string
+	// Match reports whether host belongs to this provider.
+	Match(host string) bool
+	// APIKeyEnv is the environment variable holding the API key.
+	APIKeyEnv() string
+	// HealthURL is a cheap authenticated endpoint (typically the model
+	// list) used to preflight connectivity, "" when none applies.
+	HealthURL() string
+	// Authenticate adds auth headers for key to req.
+	Authenticate(req *http.Request, key string)
+	// ParseRequest extracts request metadata from a JSON body.
+	ParseRequest(body []byte) (*RequestInfo, error)
+	// ParseResponse extracts response metadata from a JSON body.
+	ParseResponse(body []byte) (*ResponseInfo, error)
+}
+
+var registry []Provider
+
+// Register adds a provider. Providers register from init.
+func Register(p Provider) {
+	for _, existing := range registry {
+		if existing.Name() == p.Name() {
+			panic("providers: duplicate provider " + p.Name())
+		}
+	}
+	registry = append(registry, p)
+}
+
+// ForHost returns the provider owning host, or nil.
+func ForHost(host string) Provider {
+	// Strip any port.
+	if i := strings.LastIndexByte(host, ':'); i >= 0 && !strings.Contains(host[i:], "]") {
+		host = host[:i]
+	}
+	for _, p := range registry {
+		if p.Match(host) {
+			return p
+		}
+	}
+	return nil
+}
+
+// ByName returns the named provider.
+func ByName(name string) (Provider, error) {
+	for _, p := range registry {
+		if p.Name() == name {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown provider %q", name)
+}
+
+// All returns every registered provider.
+func All() []Provider {
+	return append([]Provider(nil), registry...)
+}
@@ -0,0 +1,49 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// StreamReassembler is implemented by providers that can rebuild a
+// complete response from a captured SSE stream, so traces of
+// stream: true calls still contain the full message.
+type StreamReassembler interface {
+	// ReassembleStream converts raw SSE bytes into the provider's
+	// non-streaming response JSON.
+	ReassembleStream(raw []byte) ([]byte, error)
+}
+
+// sseEvents splits a raw SSE body into the data payloads of its
+// events, dropping comments and the [DONE] sentinel.
+func sseEvents(raw []byte) []string {
+	var events []string
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	var data strings.Builder
+	flush := func() {
+		if data.Len() == 0 {
+			return
+		}
+		payload := data.String()
+		data.Reset()
+		if payload != "[DONE]" {
+			events = append(events, payload)
+		}
+	}
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	flush()
+	return events
+}
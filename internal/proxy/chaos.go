@@ -0,0 +1,74 @@
+package proxy
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Chaos injects faults into a percentage of proxied calls so retry and
+// fallback behavior can be exercised deterministically in CI.
+type Chaos struct {
+	// Rate is the fraction of calls to affect, 0..1.
+	Rate float64
+	// Faults lists the fault kinds to draw from: "latency", "429",
+	// "500", "truncate".
+	Faults []string
+	// Latency is the extra delay added by the "latency" fault.
+	Latency time.Duration
+	// Seed makes fault placement reproducible; 0 seeds from time.
+	Seed int64
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// knownFaults is the set of fault kinds the proxy implements.
+var knownFaults = map[string]bool{
+	"latency":  true,
+	"429":      true,
+	"500":      true,
+	"truncate": true,
+}
+
+// ParseChaosFaults validates a comma-separated fault list.
+func ParseChaosFaults(spec string) ([]string, error) {
+	var faults []string
+	for _, f := range strings.Split(spec, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		if !knownFaults[f] {
+			return nil, fmt.Errorf("unknown chaos fault %q", f)
+		}
+		faults = append(faults, f)
+	}
+	if len(faults) == 0 {
+		return nil, fmt.Errorf("no chaos faults given")
+	}
+	return faults, nil
+}
+
+// pick decides whether the next call is faulted and with what. It
+// returns "" for an unaffected call.
+func (c *Chaos) pick() string {
+	if c == nil || c.Rate <= 0 || len(c.Faults) == 0 {
+		return ""
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rng == nil {
+		seed := c.Seed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		c.rng = rand.New(rand.NewSource(seed))
+	}
+	if c.rng.Float64() >= c.Rate {
+		return ""
+	}
+	return c.Faults[c.rng.Intn(len(c.Faults))]
+}
@@ -0,0 +1,146 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/providers"
+)
+
+// mitmHost reports whether CONNECT tunnels to host should be
+// terminated and inspected; everything else is tunneled blind.
+func mitmHost(host string) bool {
+	return providers.ForHost(host) != nil
+}
+
+// handleConnect intercepts an HTTPS CONNECT tunnel. Provider hosts get
+// a minted certificate and full request recording; any other host is
+// passed through untouched.
+func (p *Proxy) handleConnect(w http.ResponseWriter, r *http.Request) {
+	host, port, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host, port = r.Host, "443"
+	}
+
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "hijacking unsupported", http.StatusInternalServerError)
+		return
+	}
+	clientConn, _, err := hj.Hijack()
+	if err != nil {
+		return
+	}
+	defer clientConn.Close()
+	io.WriteString(clientConn, "HTTP/1.1 200 Connection Established\r\n\r\n") //nolint:errcheck // tunnel setup
+
+	if p.opts.CA == nil || !mitmHost(host) {
+		tunnel(clientConn, net.JoinHostPort(host, port))
+		return
+	}
+
+	leaf, err := p.opts.CA.certFor(host)
+	if err != nil {
+		return
+	}
+	tlsConn := tls.Server(clientConn, &tls.Config{Certificates: []tls.Certificate{*leaf}})
+	if err := tlsConn.Handshake(); err != nil {
+		return
+	}
+	defer tlsConn.Close()
+
+	reader := bufio.NewReader(tlsConn)
+	for {
+		req, err := http.ReadRequest(reader)
+		if err != nil {
+			return
+		}
+		if !p.serveTunneledRequest(tlsConn, req, host) {
+			return
+		}
+	}
+}
+
+// serveTunneledRequest forwards one decrypted request to the real
+// provider, records it, and writes the response back into the tunnel.
+// It reports whether the tunnel should stay open.
+func (p *Proxy) serveTunneledRequest(conn net.Conn, r *http.Request, host string) bool {
+	received := time.Now()
+	reqBody, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return false
+	}
+
+	service := r.Header.Get("X-Regrada-Service")
+	if service == "" {
+		service = p.opts.Service
+	}
+	r.Header.Del("X-Regrada-Service")
+
+	target := "https://" + host + r.URL.RequestURI()
+	out, err := http.NewRequest(r.Method, target, nil)
+	if err != nil {
+		return false
+	}
+	copyHeaders(out.Header, r.Header)
+	if provider := providers.ForHost(host); provider != nil {
+		p.injectHeaders(provider.Name(), out.Header)
+	}
+
+	reqBody, err = applyRewrites(p.opts.Rewrites, out, reqBody)
+	if err != nil {
+		writeTunnelError(conn, fmt.Sprintf("rewrite failed: %v", err))
+		return false
+	}
+	out.Body = io.NopCloser(bytes.NewReader(reqBody))
+	out.ContentLength = int64(len(reqBody))
+
+	start := time.Now()
+	resp, err := p.client.Do(out)
+	if err != nil {
+		writeTunnelError(conn, err.Error())
+		return false
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return false
+	}
+	upstream := time.Since(start)
+	p.record(out, resp, reqBody, respBody, time.Since(received), upstream, "", service)
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	resp.ContentLength = int64(len(respBody))
+	resp.TransferEncoding = nil
+	if err := resp.Write(conn); err != nil {
+		return false
+	}
+	return !r.Close && !strings.EqualFold(r.Header.Get("Connection"), "close")
+}
+
+func writeTunnelError(conn net.Conn, msg string) {
+	body := fmt.Sprintf(`{"error": {"message": %q, "type": "regrada_proxy_error"}}`, msg)
+	fmt.Fprintf(conn, "HTTP/1.1 502 Bad Gateway\r\nContent-Type: application/json\r\nContent-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+// tunnel blindly copies bytes between the client and upstream for
+// hosts regrada does not inspect.
+func tunnel(client net.Conn, addr string) {
+	upstream, err := net.DialTimeout("tcp", addr, 30*time.Second)
+	if err != nil {
+		return
+	}
+	defer upstream.Close()
+	done := make(chan struct{}, 2)
+	go func() { io.Copy(upstream, client); done <- struct{}{} }() //nolint:errcheck // tunnel teardown
+	go func() { io.Copy(client, upstream); done <- struct{}{} }() //nolint:errcheck // tunnel teardown
+	<-done
+}
@@ -0,0 +1,493 @@
+// Package proxy implements the local tracing proxy that sits between
+// an application and its model providers, recording every call into a
+// trace session.
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/config"
+	"github.com/regrada-ai/regrada/internal/language"
+	"github.com/regrada-ai/regrada/internal/providers"
+	"github.com/regrada-ai/regrada/internal/trace"
+	"github.com/regrada-ai/regrada/internal/version"
+)
+
+// providerUpstreams maps the path prefix the proxy exposes to the real
+// provider endpoint. Applications point their SDK base URL at
+// http://127.0.0.1:<port>/<prefix>.
+var providerUpstreams = map[string]string{
+	"openai":    "https://api.openai.com",
+	"anthropic": "https://api.anthropic.com",
+	"gemini":    "https://generativelanguage.googleapis.com",
+	"ollama":    "http://localhost:11434",
+	"mistral":   "https://api.mistral.ai",
+}
+
+// azureUpstream returns the real Azure OpenAI endpoint from the CLI's
+// own environment. The traced application sees a proxied
+// AZURE_OPENAI_ENDPOINT, but regrada itself still runs with the
+// user's original value.
+func azureUpstream() (string, bool) {
+	ep := strings.TrimSuffix(os.Getenv("AZURE_OPENAI_ENDPOINT"), "/")
+	return ep, ep != ""
+}
+
+// Options configures a Proxy.
+type Options struct {
+	// Env tags the recorded session ("staging", "prod").
+	Env string
+	// Rewrites are applied to outgoing request bodies and headers.
+	Rewrites []config.RewriteRule
+	// Headers maps provider names to extra headers injected on every
+	// outbound request to that provider, for gateways and orgs that
+	// need more than the SDK sends.
+	Headers map[string]map[string]string
+	// Endpoints maps provider names to ordered failover base URLs that
+	// replace the default upstream: on a connection error the next
+	// entry is tried.
+	Endpoints map[string][]string
+	// InjectMetadata tags outbound request bodies with the session id
+	// (OpenAI-style user field, Anthropic metadata.user_id) so
+	// provider-side logs can be correlated back to regrada runs.
+	InjectMetadata bool
+	// Chaos, when non-nil, injects faults into a fraction of calls.
+	Chaos *Chaos
+	// Service attributes recorded calls to a service name. A caller
+	// can override it per request with the X-Regrada-Service header.
+	Service string
+	// CA, when non-nil, enables HTTPS interception: CONNECT tunnels to
+	// provider hosts are terminated with minted certificates so apps
+	// with hard-coded endpoints are still captured.
+	CA *CA
+	// Replay, when non-nil, answers requests from a recorded session
+	// instead of forwarding upstream.
+	Replay *Replayer
+}
+
+// Proxy is a recording reverse proxy for provider traffic.
+type Proxy struct {
+	opts    Options
+	client  *http.Client
+	ln      net.Listener
+	srv     *http.Server
+	mu      sync.Mutex
+	session *trace.Session
+}
+
+// New returns an unstarted Proxy recording into a fresh session.
+func New(opts Options) *Proxy {
+	return &Proxy{
+		opts:   opts,
+		client: &http.Client{Timeout: 300 * time.Second},
+		session: &trace.Session{
+			ID:             trace.NewSessionID(),
+			StartedAt:      time.Now().UTC(),
+			RegradaVersion: version.Version,
+			Env:            opts.Env,
+		},
+	}
+}
+
+// Start begins listening on a random local port and returns the base
+// URL of the proxy.
+func (p *Proxy) Start() (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	p.ln = ln
+	p.srv = &http.Server{Handler: p}
+	go p.srv.Serve(ln) //nolint:errcheck // Serve returns on Close
+	return "http://" + ln.Addr().String(), nil
+}
+
+// RunID returns the id of the session the proxy records into.
+func (p *Proxy) RunID() string { return p.session.ID }
+
+// Close stops the proxy and returns the recorded session.
+func (p *Proxy) Close() *trace.Session {
+	if p.srv != nil {
+		p.srv.Close()
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.session
+}
+
+// BaseURLEnv returns the environment variables an application needs to
+// route its SDK traffic through the proxy at base.
+func BaseURLEnv(base string) []string {
+	return []string{
+		"OPENAI_BASE_URL=" + base + "/openai/v1",
+		"ANTHROPIC_BASE_URL=" + base + "/anthropic",
+		"GOOGLE_GEMINI_BASE_URL=" + base + "/gemini",
+		"OLLAMA_HOST=" + base + "/ollama",
+		"MISTRAL_BASE_URL=" + base + "/mistral",
+		"AZURE_OPENAI_ENDPOINT=" + base + "/azure-openai",
+		"REGRADA_PROXY=" + base,
+	}
+}
+
+// MITMEnv returns the environment variables that route all HTTPS
+// traffic of an application through the proxy and trust the local CA,
+// covering SDKs that ignore base URL overrides.
+func MITMEnv(base, caCertPath string) []string {
+	return []string{
+		"HTTP_PROXY=" + base,
+		"HTTPS_PROXY=" + base,
+		"http_proxy=" + base,
+		"https_proxy=" + base,
+		"SSL_CERT_FILE=" + caCertPath,
+		"REQUESTS_CA_BUNDLE=" + caCertPath,
+		"NODE_EXTRA_CA_CERTS=" + caCertPath,
+	}
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodConnect {
+		p.handleConnect(w, r)
+		return
+	}
+	prefix, rest, ok := splitProviderPath(r.URL.Path)
+	upstream, known := providerUpstreams[prefix]
+	if prefix == "azure-openai" {
+		// Azure endpoints are per-resource, so the upstream comes from
+		// the environment instead of the static table.
+		upstream, known = azureUpstream()
+		if !known {
+			http.Error(w, "AZURE_OPENAI_ENDPOINT is not set; cannot route azure-openai traffic", http.StatusBadGateway)
+			return
+		}
+	}
+	if !ok || !known {
+		http.Error(w, fmt.Sprintf("unknown provider prefix in path %q", r.URL.Path), http.StatusBadGateway)
+		return
+	}
+
+	if isWebSocketUpgrade(r) {
+		p.handleWebSocket(w, r, upstream, rest)
+		return
+	}
+
+	received := time.Now()
+	reqBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	service := r.Header.Get("X-Regrada-Service")
+	if service == "" {
+		service = p.opts.Service
+	}
+	r.Header.Del("X-Regrada-Service")
+
+	if p.opts.Replay != nil {
+		p.serveReplay(w, r, rest, reqBody, service)
+		return
+	}
+
+	fault := p.opts.Chaos.pick()
+	switch fault {
+	case "latency":
+		time.Sleep(p.opts.Chaos.Latency)
+	case "429", "500":
+		status := http.StatusTooManyRequests
+		body := `{"error": {"message": "chaos: injected rate limit", "type": "rate_limit_error"}}`
+		if fault == "500" {
+			status = http.StatusInternalServerError
+			body = `{"error": {"message": "chaos: injected server error", "type": "server_error"}}`
+		}
+		p.recordFault(r, service, fault, status, reqBody, []byte(body))
+		w.Header().Set("Content-Type", "application/json")
+		if fault == "429" {
+			w.Header().Set("Retry-After", "1")
+		}
+		w.WriteHeader(status)
+		io.WriteString(w, body) //nolint:errcheck // client went away
+		return
+	}
+
+	endpoints := []string{upstream}
+	if alt := p.opts.Endpoints[prefix]; len(alt) > 0 {
+		endpoints = alt
+	}
+
+	target := endpoints[0] + rest
+	if r.URL.RawQuery != "" {
+		target += "?" + r.URL.RawQuery
+	}
+	out, err := http.NewRequest(r.Method, target, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	copyHeaders(out.Header, r.Header)
+	p.injectHeaders(prefix, out.Header)
+	out.Host = ""
+
+	reqBody, err = applyRewrites(p.opts.Rewrites, out, reqBody)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("rewrite failed: %v", err), http.StatusBadGateway)
+		return
+	}
+	if p.opts.InjectMetadata {
+		reqBody = injectRunMetadata(prefix, reqBody, "regrada:"+p.session.ID)
+	}
+
+	start := time.Now()
+	var resp *http.Response
+	for i, base := range endpoints {
+		attempt := out
+		if i > 0 {
+			target = base + rest
+			if r.URL.RawQuery != "" {
+				target += "?" + r.URL.RawQuery
+			}
+			attempt, err = http.NewRequest(r.Method, target, nil)
+			if err != nil {
+				continue
+			}
+			attempt.Header = out.Header.Clone()
+			attempt.Host = ""
+		}
+		attempt.Body = io.NopCloser(bytes.NewReader(reqBody))
+		attempt.ContentLength = int64(len(reqBody))
+		resp, err = p.client.Do(attempt)
+		if err == nil {
+			out = attempt
+			break
+		}
+	}
+	if resp == nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	if isEventStream(resp) && fault != "truncate" {
+		p.streamResponse(w, out, resp, reqBody, received, start, fault, service)
+		return
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	upstreamDur := time.Since(start)
+
+	p.record(out, resp, reqBody, respBody, time.Since(received), upstreamDur, fault, service)
+
+	copyHeaders(w.Header(), resp.Header)
+	w.WriteHeader(resp.StatusCode)
+	if fault == "truncate" && len(respBody) > 1 {
+		// Deliver only the first half of the body so clients see a
+		// connection cut mid-response.
+		respBody = respBody[:len(respBody)/2]
+	}
+	w.Write(respBody) //nolint:errcheck // client went away
+}
+
+// injectRunMetadata tags a JSON request body with the run id in the
+// field the provider attributes to end users, leaving any value the
+// application set itself untouched. Providers without such a field get
+// the body back unchanged.
+func injectRunMetadata(prefix string, body []byte, tag string) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return body
+	}
+	switch prefix {
+	case "openai", "azure-openai", "mistral":
+		if _, ok := obj["user"]; ok {
+			return body
+		}
+		obj["user"] = tag
+	case "anthropic":
+		md, _ := obj["metadata"].(map[string]any)
+		if _, ok := md["user_id"]; ok {
+			return body
+		}
+		if md == nil {
+			md = map[string]any{}
+		}
+		md["user_id"] = tag
+		obj["metadata"] = md
+	default:
+		return body
+	}
+	out, err := json.Marshal(obj)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+func isEventStream(resp *http.Response) bool {
+	return strings.Contains(resp.Header.Get("Content-Type"), "text/event-stream")
+}
+
+// streamResponse forwards SSE chunks to the client as they arrive,
+// flushing after every read so streaming semantics survive the proxy,
+// while teeing the bytes so the recorded call still holds the complete
+// response. After the stream ends the deltas are reassembled into the
+// provider's non-streaming response shape.
+func (p *Proxy) streamResponse(w http.ResponseWriter, out *http.Request, resp *http.Response, reqBody []byte, received, start time.Time, fault, service string) {
+	copyHeaders(w.Header(), resp.Header)
+	w.WriteHeader(resp.StatusCode)
+	flusher, _ := w.(http.Flusher)
+
+	var buf bytes.Buffer
+	chunk := make([]byte, 32*1024)
+	for {
+		n, err := resp.Body.Read(chunk)
+		if n > 0 {
+			buf.Write(chunk[:n])
+			if _, werr := w.Write(chunk[:n]); werr != nil {
+				break
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	upstream := time.Since(start)
+
+	respBody := buf.Bytes()
+	if provider := providers.ForHost(out.URL.Host); provider != nil {
+		if rs, ok := provider.(providers.StreamReassembler); ok {
+			if rebuilt, err := rs.ReassembleStream(respBody); err == nil {
+				respBody = rebuilt
+			}
+		}
+	}
+	p.record(out, resp, reqBody, respBody, time.Since(received), upstream, fault, service)
+}
+
+// record appends a call to the session, enriching it with whatever the
+// provider's parser can extract. elapsed is the total time the call
+// spent in the proxy, upstream the part waiting on the provider;
+// recording both lets users separate proxy overhead from real latency.
+func (p *Proxy) record(req *http.Request, resp *http.Response, reqBody, respBody []byte, elapsed, upstream time.Duration, fault, service string) {
+	call := trace.Call{
+		Service:    service,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		Status:     resp.StatusCode,
+		StartedAt:  time.Now().UTC().Add(-elapsed),
+		DurationMS: float64(elapsed) / float64(time.Millisecond),
+		UpstreamMS: float64(upstream) / float64(time.Millisecond),
+		Request:    bytes.Clone(reqBody),
+		Response:   bytes.Clone(respBody),
+		Fault:      fault,
+		RateLimit:  trace.ParseRateLimitHeaders(resp.Header),
+	}
+	if len(p.opts.Endpoints) > 0 {
+		// With failover configured, record which endpoint actually
+		// served the call for later analysis.
+		call.Endpoint = req.URL.Scheme + "://" + req.URL.Host
+	}
+	if provider := providers.ForHost(req.URL.Host); provider != nil {
+		call.Provider = provider.Name()
+		if info, err := provider.ParseRequest(reqBody); err == nil {
+			call.Model = info.Model
+		}
+		if info, err := provider.ParseResponse(respBody); err == nil {
+			if info.Model != "" {
+				call.Model = info.Model
+			}
+			call.ToolCalls = info.ToolCalls
+			call.PromptTokens = info.PromptTokens
+			call.CompletionTokens = info.CompletionTokens
+			call.EmbeddingInputs = info.EmbeddingInputs
+			call.EmbeddingDims = info.EmbeddingDims
+			call.Language = language.Detect(info.Text)
+		}
+		if call.Model == "" {
+			// Azure names the model via the deployment path segment.
+			call.Model = providers.AzureDeployment(req.URL.Path)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	call.Index = len(p.session.Calls)
+	p.session.Calls = append(p.session.Calls, call)
+}
+
+// recordFault appends a call that never reached the provider because a
+// chaos fault replaced the response.
+func (p *Proxy) recordFault(r *http.Request, service, fault string, status int, reqBody, respBody []byte) {
+	call := trace.Call{
+		Service:   service,
+		Method:    r.Method,
+		URL:       r.URL.String(),
+		Status:    status,
+		StartedAt: time.Now().UTC(),
+		Request:   bytes.Clone(reqBody),
+		Response:  bytes.Clone(respBody),
+		Fault:     fault,
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	call.Index = len(p.session.Calls)
+	p.session.Calls = append(p.session.Calls, call)
+}
+
+// splitProviderPath splits "/openai/v1/chat" into ("openai",
+// "/v1/chat").
+func splitProviderPath(path string) (prefix, rest string, ok bool) {
+	trimmed := strings.TrimPrefix(path, "/")
+	i := strings.IndexByte(trimmed, '/')
+	if i < 0 {
+		return trimmed, "/", trimmed != ""
+	}
+	return trimmed[:i], trimmed[i:], true
+}
+
+// hopHeaders are not forwarded between the app and the provider.
+var hopHeaders = map[string]bool{
+	"Connection":        true,
+	"Keep-Alive":        true,
+	"Transfer-Encoding": true,
+	"Upgrade":           true,
+	"Proxy-Connection":  true,
+	"Content-Length":    true,
+	"Accept-Encoding":   true,
+}
+
+// injectHeaders adds the configured per-provider headers to an
+// outbound request.
+func (p *Proxy) injectHeaders(provider string, h http.Header) {
+	for name, value := range p.opts.Headers[provider] {
+		h.Set(name, value)
+	}
+}
+
+func copyHeaders(dst, src http.Header) {
+	for name, values := range src {
+		if hopHeaders[name] || name == "Host" {
+			continue
+		}
+		for _, v := range values {
+			dst.Add(name, v)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package proxy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+// Replayer serves recorded responses in place of upstream calls (VCR
+// mode), so tests run deterministically and offline without burning
+// tokens. Requests match a recorded call by endpoint path plus a hash
+// of the normalized request body; repeated identical requests consume
+// recordings in order and then replay the last one.
+type Replayer struct {
+	mu    sync.Mutex
+	byKey map[string][]*trace.Call
+	// byEndpoint is the fallback when no body hash matches, e.g. when
+	// the request embeds a timestamp the recording does not.
+	byEndpoint map[string][]*trace.Call
+}
+
+// NewReplayer indexes a recorded session for replay.
+func NewReplayer(s *trace.Session) *Replayer {
+	r := &Replayer{byKey: map[string][]*trace.Call{}, byEndpoint: map[string][]*trace.Call{}}
+	for i := range s.Calls {
+		c := &s.Calls[i]
+		path := c.URL
+		if u, err := url.Parse(c.URL); err == nil {
+			path = u.Path
+		}
+		key := replayKey(c.Method, path, c.Request)
+		r.byKey[key] = append(r.byKey[key], c)
+		ep := c.Method + " " + path
+		r.byEndpoint[ep] = append(r.byEndpoint[ep], c)
+	}
+	return r
+}
+
+// Match returns the recorded call for a request, or nil when the
+// session holds nothing for its endpoint.
+func (r *Replayer) Match(method, path string, body []byte) *trace.Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c := pop(r.byKey, replayKey(method, path, body)); c != nil {
+		return c
+	}
+	return pop(r.byEndpoint, method+" "+path)
+}
+
+// pop takes the next recording for key, leaving the last one in place
+// so it can answer any number of further requests.
+func pop(m map[string][]*trace.Call, key string) *trace.Call {
+	calls := m[key]
+	if len(calls) == 0 {
+		return nil
+	}
+	c := calls[0]
+	if len(calls) > 1 {
+		m[key] = calls[1:]
+	}
+	return c
+}
+
+// replayKey hashes the endpoint and normalized body. JSON bodies are
+// canonicalized (re-marshalled) so key order and whitespace do not
+// break matching.
+func replayKey(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + " "))
+	h.Write(normalizeBody(body))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func normalizeBody(body []byte) []byte {
+	if len(body) == 0 {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	norm, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return norm
+}
+
+// serveReplay answers a request from the replay session and records
+// the served call into the current session.
+func (p *Proxy) serveReplay(w http.ResponseWriter, r *http.Request, rest string, reqBody []byte, service string) {
+	recorded := p.opts.Replay.Match(r.Method, rest, reqBody)
+	if recorded == nil {
+		http.Error(w, "replay: no recorded call for "+r.Method+" "+rest, http.StatusNotImplemented)
+		return
+	}
+
+	call := *recorded
+	call.Service = service
+	call.Request = append([]byte(nil), reqBody...)
+	p.mu.Lock()
+	call.Index = len(p.session.Calls)
+	p.session.Calls = append(p.session.Calls, call)
+	p.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Regrada-Replayed", "1")
+	status := recorded.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	w.Write(recorded.Response) //nolint:errcheck // client went away
+}
@@ -0,0 +1,128 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/regrada-ai/regrada/internal/config"
+)
+
+// applyRewrites applies the configured rewrite rules to an outgoing
+// request: header injection on req and dotted-path set/remove
+// operations on the JSON body. A non-JSON body passes through rules
+// with only header operations untouched.
+func applyRewrites(rules []config.RewriteRule, req *http.Request, body []byte) ([]byte, error) {
+	if len(rules) == 0 {
+		return body, nil
+	}
+	var doc any
+	parsed := false
+	if len(body) > 0 && json.Unmarshal(body, &doc) == nil {
+		parsed = true
+	}
+	for _, rule := range rules {
+		for name, value := range rule.Headers {
+			req.Header.Set(name, value)
+		}
+		if !parsed && (len(rule.Set) > 0 || len(rule.Remove) > 0) {
+			continue
+		}
+		for path, value := range rule.Set {
+			var err error
+			doc, err = setPath(doc, strings.Split(path, "."), value)
+			if err != nil {
+				return nil, fmt.Errorf("set %s: %w", path, err)
+			}
+		}
+		for _, path := range rule.Remove {
+			var err error
+			doc, err = removePath(doc, strings.Split(path, "."))
+			if err != nil {
+				return nil, fmt.Errorf("remove %s: %w", path, err)
+			}
+		}
+	}
+	if !parsed {
+		return body, nil
+	}
+	return json.Marshal(doc)
+}
+
+// setPath writes value at a dotted path, creating objects along the
+// way. Numeric segments index into arrays.
+func setPath(doc any, parts []string, value any) (any, error) {
+	if len(parts) == 0 {
+		return value, nil
+	}
+	head, rest := parts[0], parts[1:]
+	if idx, err := strconv.Atoi(head); err == nil {
+		arr, ok := doc.([]any)
+		if !ok {
+			return nil, fmt.Errorf("segment %q indexes a non-array", head)
+		}
+		if idx < 0 || idx >= len(arr) {
+			return nil, fmt.Errorf("index %d out of range", idx)
+		}
+		child, err := setPath(arr[idx], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		arr[idx] = child
+		return arr, nil
+	}
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		if doc != nil {
+			return nil, fmt.Errorf("segment %q traverses a non-object", head)
+		}
+		obj = map[string]any{}
+	}
+	child, err := setPath(obj[head], rest, value)
+	if err != nil {
+		return nil, err
+	}
+	obj[head] = child
+	return obj, nil
+}
+
+// removePath deletes the value at a dotted path. Missing paths are not
+// an error: a rule that strips an experimental param should be a no-op
+// on requests that never had it.
+func removePath(doc any, parts []string) (any, error) {
+	if len(parts) == 0 {
+		return doc, nil
+	}
+	head, rest := parts[0], parts[1:]
+	if idx, err := strconv.Atoi(head); err == nil {
+		arr, ok := doc.([]any)
+		if !ok || idx < 0 || idx >= len(arr) {
+			return doc, nil
+		}
+		if len(rest) == 0 {
+			return append(arr[:idx], arr[idx+1:]...), nil
+		}
+		child, err := removePath(arr[idx], rest)
+		if err != nil {
+			return nil, err
+		}
+		arr[idx] = child
+		return arr, nil
+	}
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return doc, nil
+	}
+	if len(rest) == 0 {
+		delete(obj, head)
+		return obj, nil
+	}
+	child, err := removePath(obj[head], rest)
+	if err != nil {
+		return nil, err
+	}
+	obj[head] = child
+	return obj, nil
+}
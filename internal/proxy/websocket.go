@@ -0,0 +1,145 @@
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  32 * 1024,
+	WriteBufferSize: 32 * 1024,
+	CheckOrigin:     func(*http.Request) bool { return true },
+}
+
+// isWebSocketUpgrade reports whether the request asks for a WebSocket
+// upgrade (as the OpenAI Realtime API does).
+func isWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
+
+// handleWebSocket relays a Realtime API connection frame by frame
+// while watching the server's event stream: every response.done event
+// becomes a recorded call carrying token usage and any function_call
+// output items.
+func (p *Proxy) handleWebSocket(w http.ResponseWriter, r *http.Request, upstream, rest string) {
+	target := strings.Replace(upstream, "https://", "wss://", 1)
+	target = strings.Replace(target, "http://", "ws://", 1) + rest
+	if r.URL.RawQuery != "" {
+		target += "?" + r.URL.RawQuery
+	}
+
+	service := r.Header.Get("X-Regrada-Service")
+	if service == "" {
+		service = p.opts.Service
+	}
+
+	header := http.Header{}
+	for name, values := range r.Header {
+		switch strings.ToLower(name) {
+		case "upgrade", "connection", "sec-websocket-key", "sec-websocket-version",
+			"sec-websocket-extensions", "host", "x-regrada-service":
+			continue
+		}
+		header[name] = values
+	}
+
+	serverConn, resp, err := websocket.DefaultDialer.Dial(target, header)
+	if err != nil {
+		status := http.StatusBadGateway
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		http.Error(w, "upstream websocket dial failed: "+err.Error(), status)
+		return
+	}
+	defer serverConn.Close()
+
+	clientConn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer clientConn.Close()
+
+	start := time.Now()
+	errc := make(chan error, 2)
+	// Client -> server: relay untouched.
+	go relayFrames(clientConn, serverConn, nil, errc)
+	// Server -> client: relay and inspect events.
+	go relayFrames(serverConn, clientConn, func(msgType int, data []byte) {
+		if msgType == websocket.TextMessage {
+			p.recordRealtimeEvent(r, target, service, data, time.Since(start))
+		}
+	}, errc)
+	<-errc
+}
+
+func relayFrames(src, dst *websocket.Conn, observe func(int, []byte), errc chan<- error) {
+	for {
+		msgType, data, err := src.ReadMessage()
+		if err != nil {
+			errc <- err
+			return
+		}
+		if observe != nil {
+			observe(msgType, data)
+		}
+		if err := dst.WriteMessage(msgType, data); err != nil {
+			errc <- err
+			return
+		}
+	}
+}
+
+// recordRealtimeEvent turns a response.done Realtime event into a
+// recorded call.
+func (p *Proxy) recordRealtimeEvent(r *http.Request, target, service string, data []byte, elapsed time.Duration) {
+	var ev struct {
+		Type     string `json:"type"`
+		Response struct {
+			Output []struct {
+				Type string `json:"type"`
+				Name string `json:"name"`
+			} `json:"output"`
+			Usage struct {
+				InputTokens  int `json:"input_tokens"`
+				OutputTokens int `json:"output_tokens"`
+			} `json:"usage"`
+		} `json:"response"`
+	}
+	if err := json.Unmarshal(data, &ev); err != nil || ev.Type != "response.done" {
+		return
+	}
+
+	call := trace.Call{
+		Provider:         "openai",
+		Service:          service,
+		Method:           "WS",
+		URL:              target,
+		Status:           http.StatusSwitchingProtocols,
+		StartedAt:        time.Now().UTC(),
+		DurationMS:       float64(elapsed.Milliseconds()),
+		Response:         append([]byte(nil), data...),
+		PromptTokens:     ev.Response.Usage.InputTokens,
+		CompletionTokens: ev.Response.Usage.OutputTokens,
+	}
+	if model := r.URL.Query().Get("model"); model != "" {
+		call.Model = model
+	}
+	for _, item := range ev.Response.Output {
+		if item.Type == "function_call" && item.Name != "" {
+			call.ToolCalls = append(call.ToolCalls, item.Name)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	call.Index = len(p.session.Calls)
+	p.session.Calls = append(p.session.Calls, call)
+}
@@ -0,0 +1,49 @@
+// Package remote fetches and pushes regrada state files from shared
+// object storage, so every CI runner compares against one canonical
+// baseline instead of a stale local copy.
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// ErrNotFound reports that the remote has no object under the key.
+var ErrNotFound = errors.New("object not found")
+
+// Client reads and writes objects under a destination prefix.
+type Client interface {
+	// Get returns the object stored under key, or ErrNotFound.
+	Get(key string) ([]byte, error)
+	// Put stores data under key.
+	Put(key string, data []byte) error
+}
+
+// Factory creates a Client for a destination URL.
+type Factory func(dest *url.URL) (Client, error)
+
+var factories = map[string]Factory{}
+
+// RegisterScheme adds a client factory for a URL scheme. Storage
+// backends register themselves from init.
+func RegisterScheme(scheme string, f Factory) {
+	if _, dup := factories[scheme]; dup {
+		panic("remote: duplicate scheme " + scheme)
+	}
+	factories[scheme] = f
+}
+
+// New returns a Client for dest, which must be a URL such as
+// s3://bucket/prefix.
+func New(dest string) (Client, error) {
+	u, err := url.Parse(dest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote %q: %w", dest, err)
+	}
+	f, ok := factories[u.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("unsupported remote scheme %q", u.Scheme)
+	}
+	return f(u)
+}
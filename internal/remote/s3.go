@@ -0,0 +1,175 @@
+package remote
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+func init() {
+	RegisterScheme("s3", newS3Client)
+}
+
+// s3Client talks to S3 (or any S3-compatible store) with
+// SigV4-signed requests. Signing by hand keeps the dependency tree
+// free of an SDK for the two verbs we need on one bucket.
+type s3Client struct {
+	bucket string
+	prefix string
+	// endpoint overrides the AWS host for S3-compatible stores (MinIO,
+	// localstack), taken from AWS_ENDPOINT_URL; objects are then
+	// addressed path-style.
+	endpoint string
+	region   string
+	client   *http.Client
+}
+
+func newS3Client(dest *url.URL) (Client, error) {
+	if dest.Host == "" {
+		return nil, fmt.Errorf("s3 remote %q has no bucket", dest)
+	}
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &s3Client{
+		bucket:   dest.Host,
+		prefix:   strings.Trim(dest.Path, "/"),
+		endpoint: strings.TrimSuffix(os.Getenv("AWS_ENDPOINT_URL"), "/"),
+		region:   region,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (c *s3Client) Get(key string) ([]byte, error) {
+	resp, err := c.do(http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("s3: GET %s: %s", key, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (c *s3Client) Put(key string, data []byte) error {
+	resp, err := c.do(http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("s3: PUT %s: %s", key, resp.Status)
+	}
+	return nil
+}
+
+func (c *s3Client) objectURL(key string) string {
+	p := path.Join("/", c.prefix, key)
+	if c.endpoint != "" {
+		return c.endpoint + "/" + c.bucket + p
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com%s", c.bucket, c.region, p)
+}
+
+func (c *s3Client) do(method, key string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(method, c.objectURL(key), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	payloadHash := sha256Hex(body)
+	req.Header.Set("x-amz-date", now.Format("20060102T150405Z"))
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+	if tok := os.Getenv("AWS_SESSION_TOKEN"); tok != "" {
+		req.Header.Set("x-amz-security-token", tok)
+	}
+	if err := signV4(req, payloadHash, c.region, now); err != nil {
+		return nil, err
+	}
+	return c.client.Do(req)
+}
+
+// signV4 adds the AWS Signature Version 4 Authorization header for an
+// S3 request whose signed headers are host plus every x-amz-* header
+// already set on it.
+func signV4(req *http.Request, payloadHash, region string, now time.Time) error {
+	access, secret := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if access == "" || secret == "" {
+		return fmt.Errorf("s3 remote needs AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(req.Header.Get(name))
+		}
+	}
+	var names []string
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	date := now.Format("20060102")
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format("20060102T150405Z"),
+		scope,
+		sha256Hex([]byte(canonical)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		access, scope, signedHeaders, signature))
+	return nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, msg string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(msg))
+	return h.Sum(nil)
+}
@@ -0,0 +1,237 @@
+// Package report renders eval results into self-contained HTML files
+// suitable for CI artifact upload.
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/regrada-ai/regrada/internal/atomicfile"
+	"github.com/regrada-ai/regrada/internal/evals"
+	"github.com/regrada-ai/regrada/internal/trace"
+	"github.com/regrada-ai/regrada/internal/worddiff"
+)
+
+// Data is everything one report renders: the run, an optional baseline
+// to diff against, and an optional trace session.
+type Data struct {
+	Results  *evals.Results
+	Baseline *evals.Results
+	Session  *trace.Session
+}
+
+// statusChange pairs a test with its status before and after.
+type statusChange struct {
+	Name   string
+	Before string
+	After  string
+}
+
+// Changes lists tests whose status differs from the baseline, skipping
+// tests the baseline does not know.
+func (d *Data) Changes() []statusChange {
+	if d.Baseline == nil {
+		return nil
+	}
+	var out []statusChange
+	for _, tr := range d.Results.Tests {
+		bt := d.Baseline.Test(tr.Name)
+		if bt == nil || bt.Status == tr.Status {
+			continue
+		}
+		out = append(out, statusChange{Name: tr.Name, Before: bt.Status, After: tr.Status})
+	}
+	return out
+}
+
+// Counts aggregates test statuses for the summary chart.
+func (d *Data) Counts() map[string]int {
+	counts := map[string]int{}
+	for _, tr := range d.Results.Tests {
+		counts[tr.Status]++
+	}
+	return counts
+}
+
+// Percent scales a count to a chart width.
+func (d *Data) Percent(n int) float64 {
+	if len(d.Results.Tests) == 0 {
+		return 0
+	}
+	return float64(n) / float64(len(d.Results.Tests)) * 100
+}
+
+// ResponseDiff renders a word-level diff of the baseline response
+// against this run's as HTML, removed words in <del> and added ones in
+// <ins>. It returns "" when there is no baseline response to compare
+// or nothing changed, and the template falls back to the plain body.
+func (d *Data) ResponseDiff(tr evals.TestResult) template.HTML {
+	if d.Baseline == nil {
+		return ""
+	}
+	bt := d.Baseline.Test(tr.Name)
+	if bt == nil || bt.Response == "" || tr.Response == "" || bt.Response == tr.Response {
+		return ""
+	}
+	ops := worddiff.Diff(bt.Response, tr.Response)
+	if !worddiff.Changed(ops) {
+		return ""
+	}
+	var b strings.Builder
+	for _, op := range ops {
+		text := template.HTMLEscapeString(op.Text)
+		switch op.Kind {
+		case '-':
+			b.WriteString("<del>" + text + "</del>")
+		case '+':
+			b.WriteString("<ins>" + text + "</ins>")
+		default:
+			b.WriteString(text)
+		}
+	}
+	return template.HTML(b.String())
+}
+
+// Markdown renders the run as pull-request-comment markdown: verdict,
+// summary table, and status changes against the baseline.
+func Markdown(d *Data) string {
+	var b strings.Builder
+	counts := d.Counts()
+	verdict := "✅ passing"
+	if counts["fail"] > 0 || counts["error"] > 0 {
+		verdict = "❌ failing"
+	}
+	fmt.Fprintf(&b, "## regrada: %s\n\n", verdict)
+	fmt.Fprintf(&b, "Suite `%s`, run `%s` — score %.2f over %d tests.\n\n",
+		d.Results.Suite, d.Results.RunID, d.Results.Score, len(d.Results.Tests))
+	if d.Results.CostUSD > 0 {
+		fmt.Fprintf(&b, "Estimated provider cost: $%.4f", d.Results.CostUSD)
+		if d.Baseline != nil && d.Baseline.CostUSD > 0 {
+			fmt.Fprintf(&b, " (baseline $%.4f, %+.4f)", d.Baseline.CostUSD, d.Results.CostUSD-d.Baseline.CostUSD)
+		}
+		b.WriteString("\n\n")
+	}
+	b.WriteString("| Status | Tests |\n| --- | --- |\n")
+	for _, status := range []string{"pass", "fail", "error", "infra_error", "skipped", "xfail", "xpass"} {
+		if counts[status] > 0 {
+			fmt.Fprintf(&b, "| %s | %d |\n", status, counts[status])
+		}
+	}
+	b.WriteString("\n")
+	if d.Results.ChangeSummary != "" {
+		fmt.Fprintf(&b, "### Change summary\n\n%s\n\n", d.Results.ChangeSummary)
+	}
+	if changes := d.Changes(); len(changes) > 0 {
+		b.WriteString("### Changes against baseline\n\n| Test | Baseline | This run |\n| --- | --- | --- |\n")
+		for _, ch := range changes {
+			fmt.Fprintf(&b, "| `%s` | %s | %s |\n", ch.Name, ch.Before, ch.After)
+		}
+		b.WriteString("\n")
+	}
+	for _, tr := range d.Results.Tests {
+		if tr.Status != evals.StatusFail && tr.Status != evals.StatusError {
+			continue
+		}
+		for _, c := range tr.Checks {
+			if !c.Passed {
+				fmt.Fprintf(&b, "- `%s`: %s — %s\n", tr.Name, c.Name, c.Message)
+			}
+		}
+	}
+	return b.String()
+}
+
+// WriteHTML renders the report as one standalone HTML file at path.
+func WriteHTML(d *Data, path string) error {
+	var buf bytes.Buffer
+	if err := reportTemplate.Execute(&buf, d); err != nil {
+		return fmt.Errorf("rendering report: %w", err)
+	}
+	return atomicfile.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>regrada — {{.Results.Suite}} {{.Results.RunID}}</title>
+<style>
+body { font: 14px/1.5 -apple-system, "Segoe UI", sans-serif; margin: 2rem auto; max-width: 60rem; color: #1a1a1a; }
+h1 { font-size: 1.3rem; }
+h2 { font-size: 1.1rem; margin-top: 2rem; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.3rem 0.6rem; border-bottom: 1px solid #e0e0e0; }
+.chart { display: flex; height: 1.2rem; border-radius: 3px; overflow: hidden; margin: 0.5rem 0; }
+.chart div { height: 100%; }
+.badge { padding: 0.1rem 0.4rem; border-radius: 3px; font-size: 0.85em; color: #fff; }
+.pass, .xpass { background: #2a9d4a; }
+.fail, .error { background: #d64545; }
+.infra_error { background: #d68f45; }
+.skipped, .xfail { background: #8a8a8a; }
+details { margin: 0.5rem 0; border: 1px solid #e0e0e0; border-radius: 4px; padding: 0.4rem 0.8rem; }
+summary { cursor: pointer; }
+pre { background: #f6f6f6; padding: 0.6rem; overflow-x: auto; white-space: pre-wrap; }
+pre del { background: #fdd; text-decoration: none; }
+pre ins { background: #dfd; text-decoration: none; }
+.muted { color: #777; }
+</style>
+</head>
+<body>
+<h1>{{.Results.Suite}} <span class="muted">run {{.Results.RunID}}</span></h1>
+<p>Started {{.Results.StartedAt.Format "2006-01-02 15:04:05 MST"}} — score {{printf "%.2f" .Results.Score}} over {{len .Results.Tests}} tests in {{printf "%.1fs" .Results.Duration}}.{{with .Results.CostUSD}} Estimated cost ${{printf "%.4f" .}}.{{end}}</p>
+{{$d := .}}
+<div class="chart">
+{{- range $status, $n := .Counts}}
+<div class="{{$status}}" style="width: {{printf "%.1f" ($d.Percent $n)}}%" title="{{$n}} {{$status}}"></div>
+{{- end}}
+</div>
+<p>
+{{- range $status, $n := .Counts}}
+<span class="badge {{$status}}">{{$status}}: {{$n}}</span>
+{{- end}}
+</p>
+
+{{with .Results.ChangeSummary}}
+<h2>Change summary</h2>
+<p>{{.}}</p>
+{{end}}
+
+{{with .Changes}}
+<h2>Changes against baseline</h2>
+<table>
+<tr><th>Test</th><th>Baseline</th><th>This run</th></tr>
+{{range .}}<tr><td>{{.Name}}</td><td><span class="badge {{.Before}}">{{.Before}}</span></td><td><span class="badge {{.After}}">{{.After}}</span></td></tr>
+{{end}}
+</table>
+{{end}}
+
+<h2>Tests</h2>
+{{range .Results.Tests}}
+<details{{if eq .Status "fail" "error"}} open{{end}}>
+<summary><span class="badge {{.Status}}">{{.Status}}</span> {{.Name}} <span class="muted">score {{printf "%.2f" .Score}}{{with .Reason}} — {{.}}{{end}}</span></summary>
+{{if .Checks}}
+<table>
+<tr><th>Check</th><th>Outcome</th><th>Message</th></tr>
+{{range .Checks}}<tr><td>{{.Name}}</td><td>{{if .Passed}}pass{{else}}fail{{end}}</td><td>{{.Message}}</td></tr>
+{{end}}
+</table>
+{{end}}
+{{with .Prompt}}<p class="muted">Prompt</p><pre>{{.}}</pre>{{end}}
+{{with $d.ResponseDiff .}}<p class="muted">Response (vs baseline)</p><pre>{{.}}</pre>
+{{else}}{{with .Response}}<p class="muted">Response</p><pre>{{.}}</pre>{{end}}{{end}}
+</details>
+{{end}}
+
+{{with .Session}}
+<h2>Trace session {{.ID}}</h2>
+<table>
+<tr><th>#</th><th>Model</th><th>Status</th><th>Duration</th><th>URL</th></tr>
+{{range .Calls}}<tr><td>{{.Index}}</td><td>{{.Model}}</td><td>{{.Status}}</td><td>{{printf "%.0fms" .DurationMS}}</td><td>{{.URL}}</td></tr>
+{{end}}
+</table>
+{{end}}
+</body>
+</html>
+`))
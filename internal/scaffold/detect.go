@@ -0,0 +1,118 @@
+// Package scaffold generates starter configuration for new projects
+// and detects how an existing codebase talks to model providers.
+package scaffold
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Project describes what was detected in a target directory.
+type Project struct {
+	// Language is "python", "node", "go" or "" when nothing matched.
+	Language string
+	// Providers lists provider SDKs found in the dependency manifest
+	// ("openai", "anthropic", ...).
+	Providers []string
+	// RunHint is an example command for tracing the app in this
+	// language.
+	RunHint string
+}
+
+// sdkMarkers maps dependency-name substrings to provider names.
+var sdkMarkers = map[string]string{
+	"openai":              "openai",
+	"anthropic":           "anthropic",
+	"google-generativeai": "gemini",
+	"generative-ai":       "gemini",
+	"mistral":             "mistral",
+	"ollama":              "ollama",
+}
+
+// Detect inspects dir for dependency manifests and returns what it
+// found. Detection is best-effort: a project with no manifest simply
+// yields an empty Project.
+func Detect(dir string) Project {
+	if p, ok := detectPython(dir); ok {
+		return p
+	}
+	if p, ok := detectNode(dir); ok {
+		return p
+	}
+	if p, ok := detectGo(dir); ok {
+		return p
+	}
+	return Project{}
+}
+
+func detectPython(dir string) (Project, bool) {
+	var deps string
+	for _, name := range []string{"requirements.txt", "pyproject.toml"} {
+		if data, err := os.ReadFile(filepath.Join(dir, name)); err == nil {
+			deps += strings.ToLower(string(data)) + "\n"
+		}
+	}
+	if deps == "" {
+		return Project{}, false
+	}
+	return Project{
+		Language:  "python",
+		Providers: matchProviders(deps),
+		RunHint:   "regrada trace -- python app.py",
+	}, true
+}
+
+func detectNode(dir string) (Project, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return Project{}, false
+	}
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	deps := strings.ToLower(string(data))
+	if json.Unmarshal(data, &pkg) == nil {
+		var names []string
+		for name := range pkg.Dependencies {
+			names = append(names, name)
+		}
+		for name := range pkg.DevDependencies {
+			names = append(names, name)
+		}
+		deps = strings.ToLower(strings.Join(names, "\n"))
+	}
+	return Project{
+		Language:  "node",
+		Providers: matchProviders(deps),
+		RunHint:   "regrada trace -- node index.js",
+	}, true
+}
+
+func detectGo(dir string) (Project, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return Project{}, false
+	}
+	return Project{
+		Language:  "go",
+		Providers: matchProviders(strings.ToLower(string(data))),
+		RunHint:   "regrada trace -- go run .",
+	}, true
+}
+
+func matchProviders(deps string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for marker, provider := range sdkMarkers {
+		if strings.Contains(deps, marker) && !seen[provider] {
+			seen[provider] = true
+			out = append(out, provider)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
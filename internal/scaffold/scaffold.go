@@ -0,0 +1,121 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Options controls what Write generates.
+type Options struct {
+	// Dir is the project root to scaffold into.
+	Dir string
+	// Project is the detection result used to tailor the output.
+	Project Project
+	// Force overwrites files that already exist.
+	Force bool
+	// Template selects a preset suite; nil scaffolds the generic
+	// example.
+	Template *Template
+}
+
+// Write generates .regrada.yaml, a starter suite with prompts, and a
+// CI workflow in dir. Existing files are left alone unless Force is
+// set; the returned list names the files actually written.
+func Write(opts Options) ([]string, error) {
+	cfg := configTemplate(opts.Project)
+	files := map[string]string{
+		"evals/tests.yaml":          suiteTemplate(),
+		"evals/prompts/example.txt": examplePrompt,
+	}
+	if t := opts.Template; t != nil {
+		files = map[string]string{}
+		for rel, content := range t.Files {
+			files[rel] = content
+		}
+		cfg += t.GateSettings
+	}
+	files[".regrada.yaml"] = cfg
+	files[".github/workflows/ai-tests.yml"] = workflowTemplate(opts.Project)
+	var written []string
+	for rel, content := range files {
+		path := filepath.Join(opts.Dir, filepath.FromSlash(rel))
+		if !opts.Force {
+			if _, err := os.Stat(path); err == nil {
+				continue
+			}
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return written, err
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return written, err
+		}
+		written = append(written, rel)
+	}
+	return written, nil
+}
+
+func configTemplate(p Project) string {
+	providers := ""
+	for _, name := range p.Providers {
+		providers += fmt.Sprintf("  %s: {}\n", name)
+	}
+	cfg := "version: 1\n\nevals:\n  suite: evals/tests.yaml\n  concurrent: 1\n"
+	if providers != "" {
+		cfg += "\nproviders:\n" + providers
+	}
+	return cfg
+}
+
+func suiteTemplate() string {
+	return `name: ai-tests
+description: Behavioral tests for the AI surface of this app
+
+tests:
+  - name: example_greeting
+    prompt: prompts/example.txt
+    checks:
+      - "sentiment:positive"
+      - stays_on_topic
+`
+}
+
+const examplePrompt = `You are a helpful assistant.
+User: Hello!
+`
+
+func workflowTemplate(p Project) string {
+	setup := ""
+	switch p.Language {
+	case "python":
+		setup = `      - uses: actions/setup-python@v5
+        with:
+          python-version: "3.12"
+      - run: pip install -r requirements.txt
+`
+	case "node":
+		setup = `      - uses: actions/setup-node@v4
+        with:
+          node-version: "20"
+      - run: npm ci
+`
+	case "go":
+		setup = `      - uses: actions/setup-go@v5
+        with:
+          go-version: stable
+`
+	}
+	return `name: AI Tests
+on:
+  pull_request:
+
+jobs:
+  regrada:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+` + setup + `      - name: Run AI tests
+        run: regrada run
+`
+}
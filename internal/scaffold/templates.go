@@ -0,0 +1,129 @@
+package scaffold
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Template is a named preset of starter files tuned for one agent
+// archetype.
+type Template struct {
+	Name        string
+	Description string
+	// Files maps project-relative paths to contents, excluding
+	// .regrada.yaml and the CI workflow which are shared.
+	Files map[string]string
+	// GateSettings is appended to the generated .regrada.yaml.
+	GateSettings string
+}
+
+var templates = map[string]*Template{
+	"chatbot":    chatbotTemplate,
+	"rag-agent":  ragAgentTemplate,
+	"tool-agent": toolAgentTemplate,
+}
+
+// TemplateNames lists the available template names.
+func TemplateNames() []string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupTemplate returns the named template.
+func LookupTemplate(name string) (*Template, error) {
+	t, ok := templates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown template %q (available: %s)", name, strings.Join(TemplateNames(), ", "))
+	}
+	return t, nil
+}
+
+var chatbotTemplate = &Template{
+	Name:        "chatbot",
+	Description: "Conversational assistant: tone, topic and safety checks",
+	Files: map[string]string{
+		"evals/tests.yaml": `name: chatbot-tests
+description: Behavioral tests for a conversational assistant
+
+tests:
+  - name: greeting_tone
+    prompt: prompts/greeting.txt
+    checks:
+      - "sentiment:positive"
+      - no_hallucination
+
+  - name: off_topic_deflection
+    prompt: prompts/off_topic.txt
+    checks:
+      - stays_on_topic
+`,
+		"evals/prompts/greeting.txt":  "You are a helpful assistant.\nUser: Hello!\n",
+		"evals/prompts/off_topic.txt": "You are a customer service bot for a shoe store.\nUser: What's the capital of France?\n",
+	},
+	GateSettings: `
+gate:
+  fail_on: regression
+`,
+}
+
+var ragAgentTemplate = &Template{
+	Name:        "rag-agent",
+	Description: "Retrieval-augmented agent: grounding and citation checks",
+	Files: map[string]string{
+		"evals/tests.yaml": `name: rag-tests
+description: Grounding tests for a retrieval-augmented agent
+
+tests:
+  - name: grounded_answer
+    prompt: prompts/grounded_question.txt
+    checks:
+      - no_hallucination
+      - stays_on_topic
+
+  - name: answer_schema
+    prompt: prompts/structured_question.txt
+    checks:
+      - schema_valid
+`,
+		"evals/prompts/grounded_question.txt":   "Answer using only the provided context.\nContext: The return window is 30 days.\nUser: How long do I have to return an item?\n",
+		"evals/prompts/structured_question.txt": "Respond with a JSON object {\"answer\": string, \"sources\": [string]}.\nUser: What is the return window?\n",
+	},
+	GateSettings: `
+gate:
+  fail_on: regression
+`,
+}
+
+var toolAgentTemplate = &Template{
+	Name:        "tool-agent",
+	Description: "Tool-calling agent: tool selection and schema checks",
+	Files: map[string]string{
+		"evals/tests.yaml": `name: tool-agent-tests
+description: Tool-use tests for a function-calling agent
+
+tests:
+  - name: refund_uses_tool
+    prompt: prompts/refund.txt
+    checks:
+      - schema_valid
+      - "tool_called:refund.create"
+
+  - name: lookup_uses_tool
+    prompt: prompts/order_lookup.txt
+    checks:
+      - schema_valid
+      - "tool_called:orders.get"
+`,
+		"evals/prompts/refund.txt":       "You are a customer service agent. Process this refund request.\n\nCustomer: I want a refund for order #12345, it arrived damaged.\n",
+		"evals/prompts/order_lookup.txt": "You are a customer service agent.\n\nCustomer: Where is my order #98765?\n",
+	},
+	GateSettings: `
+gate:
+  fail_on: regression
+`,
+}
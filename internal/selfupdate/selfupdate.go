@@ -0,0 +1,153 @@
+// Package selfupdate replaces the running regrada binary with the
+// latest release. Most users install a single static binary outside
+// any package manager, so the CLI has to be able to update itself.
+package selfupdate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ReleaseURL is the endpoint queried for the latest release. It is a
+// variable so tests and mirrors can point elsewhere.
+var ReleaseURL = "https://api.github.com/repos/regrada-ai/regrada/releases/latest"
+
+var client = &http.Client{Timeout: 60 * time.Second}
+
+// Release describes an available release.
+type Release struct {
+	Version string
+	// assetURL is the download URL of the binary for this platform.
+	assetURL string
+	// checksumURL is the download URL of the checksums file.
+	checksumURL string
+}
+
+// Check queries the release endpoint and returns the latest release
+// with the asset matching this OS and architecture.
+func Check() (*Release, error) {
+	resp, err := client.Get(ReleaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("checking for release: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("checking for release: %s", resp.Status)
+	}
+	var rel struct {
+		TagName string `json:"tag_name"`
+		Assets  []struct {
+			Name string `json:"name"`
+			URL  string `json:"browser_download_url"`
+		} `json:"assets"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
+		return nil, fmt.Errorf("parsing release: %w", err)
+	}
+
+	want := fmt.Sprintf("regrada_%s_%s", runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		want += ".exe"
+	}
+	out := &Release{Version: rel.TagName}
+	for _, a := range rel.Assets {
+		switch {
+		case a.Name == want:
+			out.assetURL = a.URL
+		case a.Name == "checksums.txt":
+			out.checksumURL = a.URL
+		}
+	}
+	if out.assetURL == "" {
+		return nil, fmt.Errorf("release %s has no asset %s", rel.TagName, want)
+	}
+	return out, nil
+}
+
+// Apply downloads the release binary, verifies its checksum and
+// replaces the current executable in place. The swap is a rename in
+// the executable's directory, so a failed download never leaves a
+// half-written binary on PATH.
+func (r *Release) Apply() error {
+	data, err := download(r.assetURL)
+	if err != nil {
+		return err
+	}
+	if r.checksumURL == "" {
+		return fmt.Errorf("release %s publishes no checksums.txt; refusing to install unverified binary", r.Version)
+	}
+	sums, err := download(r.checksumURL)
+	if err != nil {
+		return err
+	}
+	if err := verifyChecksum(data, sums, filepath.Base(r.assetURL)); err != nil {
+		return err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	exe, err = filepath.EvalSymlinks(exe)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".regrada-update-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpName, 0o755); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, exe)
+}
+
+func download(url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// verifyChecksum checks data against the entry for name in a
+// sha256sum-format checksums file.
+func verifyChecksum(data, sums []byte, name string) error {
+	sum := sha256.Sum256(data)
+	got := hex.EncodeToString(sum[:])
+	for _, line := range strings.Split(string(sums), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if strings.TrimPrefix(fields[1], "*") == name {
+			if fields[0] != got {
+				return fmt.Errorf("checksum mismatch for %s: want %s, got %s", name, fields[0], got)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("no checksum entry for %s", name)
+}
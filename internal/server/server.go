@@ -0,0 +1,195 @@
+// Package server exposes regrada state over HTTP so several repos and
+// CI jobs share one source of truth: runners upload trace sessions and
+// run results, promote baselines, and query history through a small
+// token-authenticated JSON API instead of each machine keeping its own
+// .regrada directory.
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/regrada-ai/regrada/internal/evals"
+	"github.com/regrada-ai/regrada/internal/store"
+	"github.com/regrada-ai/regrada/internal/trace"
+)
+
+// Prefix is the base path of the HTTP API.
+const Prefix = "/api/v1/projects/"
+
+// projectName guards against path traversal in the project segment.
+var projectName = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// Server holds one store per project under a root directory.
+type Server struct {
+	dir   string
+	token string
+}
+
+// New returns a Server rooted at dir. When token is non-empty every
+// request must carry it as a bearer token.
+func New(dir, token string) *Server {
+	return &Server{dir: dir, token: token}
+}
+
+// Handler returns the API handler.
+func (s *Server) Handler() http.Handler {
+	return http.HandlerFunc(s.serve)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	if s.token != "" && r.Header.Get("Authorization") != "Bearer "+s.token {
+		http.Error(w, "missing or invalid token", http.StatusUnauthorized)
+		return
+	}
+	rest, ok := strings.CutPrefix(r.URL.Path, Prefix)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	project, resource, _ := strings.Cut(rest, "/")
+	if !projectName.MatchString(project) {
+		http.Error(w, "invalid project name", http.StatusBadRequest)
+		return
+	}
+	st := store.Open(filepath.Join(s.dir, project))
+
+	switch {
+	case resource == "sessions" && r.Method == http.MethodPost:
+		s.uploadSession(w, r, st)
+	case resource == "results" && r.Method == http.MethodPost:
+		s.uploadResults(w, r, st)
+	case resource == "baseline/promote" && r.Method == http.MethodPost:
+		s.promote(w, r, st)
+	case resource == "baseline" && r.Method == http.MethodGet:
+		serveFile(w, st.ResultsBaselinePath())
+	case resource == "runs" && r.Method == http.MethodGet:
+		s.listRuns(w, st)
+	case strings.HasPrefix(resource, "runs/") && r.Method == http.MethodGet:
+		runID := strings.TrimPrefix(resource, "runs/")
+		if !projectName.MatchString(runID) {
+			http.Error(w, "invalid run id", http.StatusBadRequest)
+			return
+		}
+		serveFile(w, filepath.Join(st.HistoryDir(), runID+".json"))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// uploadSession stores a posted trace session under the project.
+func (s *Server) uploadSession(w http.ResponseWriter, r *http.Request, st *store.Store) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var session trace.Session
+	if err := json.Unmarshal(data, &session); err != nil || session.ID == "" {
+		http.Error(w, "body is not a trace session", http.StatusBadRequest)
+		return
+	}
+	path, err := session.Save(st.SessionsDir())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]any{"id": session.ID, "calls": len(session.Calls), "path": path})
+}
+
+// uploadResults stores posted run results as the project's latest run
+// and appends them to its history.
+func (s *Server) uploadResults(w http.ResponseWriter, r *http.Request, st *store.Store) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var res evals.Results
+	if err := json.Unmarshal(data, &res); err != nil || res.RunID == "" {
+		http.Error(w, "body is not an eval results file", http.StatusBadRequest)
+		return
+	}
+	if err := st.EnsureDir(st.HistoryDir()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := res.Save(st.ResultsPath()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := res.Save(filepath.Join(st.HistoryDir(), res.RunID+".json")); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]any{"run_id": res.RunID, "tests": len(res.Tests)})
+}
+
+// promote copies a run's results (the latest, or ?run=<id> from
+// history) into the project's results baseline.
+func (s *Server) promote(w http.ResponseWriter, r *http.Request, st *store.Store) {
+	path := st.ResultsPath()
+	if runID := r.URL.Query().Get("run"); runID != "" {
+		if !projectName.MatchString(runID) {
+			http.Error(w, "invalid run id", http.StatusBadRequest)
+			return
+		}
+		path = filepath.Join(st.HistoryDir(), runID+".json")
+	}
+	res, err := evals.LoadResults(path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err := res.Save(st.ResultsBaselinePath()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]any{"run_id": res.RunID, "score": res.Score})
+}
+
+// listRuns returns the project's history run ids, newest first.
+func (s *Server) listRuns(w http.ResponseWriter, st *store.Store) {
+	matches, err := filepath.Glob(filepath.Join(st.HistoryDir(), "*.json"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(matches)))
+	runs := []string{}
+	for _, path := range matches {
+		runs = append(runs, strings.TrimSuffix(filepath.Base(path), ".json"))
+	}
+	writeJSON(w, map[string]any{"runs": runs})
+}
+
+func serveFile(w http.ResponseWriter, path string) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data) //nolint:errcheck // best-effort response body
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, "%s\n", data) //nolint:errcheck // best-effort response body
+}
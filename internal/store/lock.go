@@ -0,0 +1,95 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// Lock is a held project run lock.
+type Lock struct {
+	path string
+}
+
+// lockInfo is what the lock file records about its holder, so a stale
+// or competing lock can be explained to the user.
+type lockInfo struct {
+	PID       int       `json:"pid"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// LockPath returns the path of the run lock file.
+func (s *Store) LockPath() string {
+	return filepath.Join(s.root, "lock")
+}
+
+// AcquireLock takes the project run lock so concurrent run/trace
+// invocations cannot corrupt results and session files. A lock whose
+// holder process is gone is treated as stale and replaced.
+func (s *Store) AcquireLock() (*Lock, error) {
+	if err := s.EnsureDir(s.root); err != nil {
+		return nil, err
+	}
+	path := s.LockPath()
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			data, merr := json.Marshal(lockInfo{PID: os.Getpid(), StartedAt: time.Now().UTC()})
+			if merr == nil {
+				_, merr = f.Write(append(data, '\n'))
+			}
+			f.Close()
+			if merr != nil {
+				os.Remove(path)
+				return nil, merr
+			}
+			return &Lock{path: path}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		info, rerr := readLockInfo(path)
+		if rerr == nil && info.PID > 0 && processAlive(info.PID) {
+			return nil, fmt.Errorf("another regrada invocation (pid %d, started %s) holds the lock at %s; pass --no-lock to override",
+				info.PID, info.StartedAt.Format(time.RFC3339), path)
+		}
+		// Holder is gone (or the file is unreadable garbage): the lock
+		// is stale, remove it and retry once.
+		if rerr := os.Remove(path); rerr != nil && !os.IsNotExist(rerr) {
+			return nil, rerr
+		}
+	}
+	return nil, fmt.Errorf("could not acquire lock at %s", path)
+}
+
+// Release drops the lock.
+func (l *Lock) Release() {
+	if l != nil {
+		os.Remove(l.path)
+	}
+}
+
+func readLockInfo(path string) (*lockInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var info lockInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// processAlive reports whether pid exists on this machine.
+func processAlive(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = proc.Signal(syscall.Signal(0))
+	return err == nil || err == syscall.EPERM
+}
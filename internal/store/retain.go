@@ -0,0 +1,67 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Retention is a history lifecycle policy: cap the number of recent
+// runs kept in full, and collapse older records to one per day so
+// long-lived projects keep a trend line without unbounded growth.
+type Retention struct {
+	// Runs keeps at most this many of the most recent history records
+	// (0 keeps all recent records).
+	Runs int
+	// RollupAfterDays collapses records older than this many days to
+	// the last run of each day (0 disables rollups).
+	RollupAfterDays int
+}
+
+// Enabled reports whether the policy would ever remove anything.
+func (r Retention) Enabled() bool {
+	return r.Runs > 0 || r.RollupAfterDays > 0
+}
+
+// Prune applies the retention policy to the history directory and
+// returns how many records it removed. Recency and age come from the
+// run-id file names (20060102-150405-xxxxxxxx.json), which sort
+// chronologically, so no record needs to be parsed.
+func (s *Store) Prune(r Retention, now time.Time) (int, error) {
+	matches, err := filepath.Glob(filepath.Join(s.HistoryDir(), "*.json"))
+	if err != nil {
+		return 0, err
+	}
+	// Newest first: the run-id prefix is a UTC timestamp.
+	sort.Sort(sort.Reverse(sort.StringSlice(matches)))
+
+	cutoffDay := now.UTC().AddDate(0, 0, -r.RollupAfterDays).Format("20060102")
+	keptDay := map[string]bool{}
+	removed := 0
+	for i, path := range matches {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		day := name
+		if len(name) >= 8 {
+			day = name[:8]
+		}
+		var keep bool
+		if r.RollupAfterDays > 0 && day < cutoffDay {
+			// Beyond the rollup horizon only the last run of each
+			// day survives.
+			keep = !keptDay[day]
+			keptDay[day] = true
+		} else {
+			keep = r.Runs == 0 || i < r.Runs
+		}
+		if keep {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,105 @@
+// Package store manages the on-disk layout of a project's .regrada
+// state directory: baselines, run results, history and reports.
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Store provides access to the files under a project's .regrada
+// directory.
+type Store struct {
+	root string
+}
+
+// Open returns a Store rooted at dir. The directory is created on
+// first write, not here, so read-only commands work without leaving
+// state behind.
+func Open(dir string) *Store {
+	return &Store{root: dir}
+}
+
+// Root returns the state directory path.
+func (s *Store) Root() string { return s.root }
+
+// BaselinePath returns the path of the trace/test baseline file.
+func (s *Store) BaselinePath() string {
+	return filepath.Join(s.root, "baseline.json")
+}
+
+// ResultsBaselinePath returns the path of the promoted eval-results
+// baseline. It is distinct from BaselinePath, which records test
+// statuses: the two files have different shapes and must not be
+// conflated.
+func (s *Store) ResultsBaselinePath() string {
+	return filepath.Join(s.root, "results-baseline.json")
+}
+
+// BaselinesDir returns the directory holding archived prior results
+// baselines, one per promoted run.
+func (s *Store) BaselinesDir() string {
+	return filepath.Join(s.root, "baselines")
+}
+
+// ResultsPath returns the path of the most recent run's results.
+func (s *Store) ResultsPath() string {
+	return filepath.Join(s.root, "results.json")
+}
+
+// HistoryDir returns the directory holding per-run history records.
+func (s *Store) HistoryDir() string {
+	return filepath.Join(s.root, "history")
+}
+
+// ReportsDir returns the directory holding generated HTML reports.
+func (s *Store) ReportsDir() string {
+	return filepath.Join(s.root, "reports")
+}
+
+// QuarantinePath returns the path of the flaky-test quarantine file.
+func (s *Store) QuarantinePath() string {
+	return filepath.Join(s.root, "quarantine.json")
+}
+
+// GatesPath returns the path of the gate policy file.
+func (s *Store) GatesPath() string {
+	return filepath.Join(s.root, "gates.yaml")
+}
+
+// ArtifactsDir returns the directory holding per-test run artifacts.
+func (s *Store) ArtifactsDir() string {
+	return filepath.Join(s.root, "artifacts")
+}
+
+// SessionsDir returns the directory holding recorded trace sessions.
+func (s *Store) SessionsDir() string {
+	return filepath.Join(s.root, "sessions")
+}
+
+// EnsureDir creates dir (and parents) if needed.
+func (s *Store) EnsureDir(dir string) error {
+	return os.MkdirAll(dir, 0o755)
+}
+
+// LatestFile returns the most recently modified file in dir matching
+// the glob pattern, or "" if there are none.
+func LatestFile(dir, pattern string) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", nil
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		fi, erri := os.Stat(matches[i])
+		fj, errj := os.Stat(matches[j])
+		if erri != nil || errj != nil {
+			return matches[i] < matches[j]
+		}
+		return fi.ModTime().After(fj.ModTime())
+	})
+	return matches[0], nil
+}
@@ -0,0 +1,100 @@
+package trace
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// chromeEvent is one entry in the Chrome trace-event format, which
+// Perfetto and chrome://tracing both load. Timestamps and durations
+// are microseconds.
+type chromeEvent struct {
+	Name  string         `json:"name"`
+	Phase string         `json:"ph"`
+	TS    int64          `json:"ts"`
+	Dur   int64          `json:"dur,omitempty"`
+	PID   int            `json:"pid"`
+	TID   int            `json:"tid"`
+	Args  map[string]any `json:"args,omitempty"`
+}
+
+// ChromeTrace renders a session in Chrome trace-event format with one
+// track (thread) per provider and a complete-span per call, so agent
+// call concurrency and latency stacking can be inspected in familiar
+// flame-graph tooling.
+func ChromeTrace(s *Session) ([]byte, error) {
+	// Assign stable track ids by provider name, sorted for determinism.
+	providerSet := map[string]bool{}
+	for _, c := range s.Calls {
+		name := c.Provider
+		if name == "" {
+			name = "unknown"
+		}
+		providerSet[name] = true
+	}
+	var providerNames []string
+	for name := range providerSet {
+		providerNames = append(providerNames, name)
+	}
+	sort.Strings(providerNames)
+	tids := map[string]int{}
+	for i, name := range providerNames {
+		tids[name] = i + 1
+	}
+
+	var events []chromeEvent
+	for name, tid := range tids {
+		events = append(events, chromeEvent{
+			Name:  "thread_name",
+			Phase: "M",
+			PID:   1,
+			TID:   tid,
+			Args:  map[string]any{"name": name},
+		})
+	}
+	for _, c := range s.Calls {
+		provider := c.Provider
+		if provider == "" {
+			provider = "unknown"
+		}
+		name := c.Model
+		if name == "" {
+			name = c.URL
+		}
+		args := map[string]any{
+			"url":    c.URL,
+			"status": c.Status,
+		}
+		if c.Service != "" {
+			args["service"] = c.Service
+		}
+		if c.Fault != "" {
+			args["fault"] = c.Fault
+		}
+		if c.PromptTokens > 0 || c.CompletionTokens > 0 {
+			args["prompt_tokens"] = c.PromptTokens
+			args["completion_tokens"] = c.CompletionTokens
+		}
+		events = append(events, chromeEvent{
+			Name:  name,
+			Phase: "X",
+			TS:    c.StartedAt.Sub(s.StartedAt).Microseconds(),
+			Dur:   int64(c.DurationMS * 1000),
+			PID:   1,
+			TID:   tids[provider],
+			Args:  args,
+		})
+	}
+	// Metadata events come first and spans keep call order; sort by
+	// timestamp with metadata (ts 0, phase M) pinned up front.
+	sort.SliceStable(events, func(i, j int) bool {
+		if (events[i].Phase == "M") != (events[j].Phase == "M") {
+			return events[i].Phase == "M"
+		}
+		return events[i].TS < events[j].TS
+	})
+	return json.MarshalIndent(map[string]any{
+		"displayTimeUnit": "ms",
+		"traceEvents":     events,
+	}, "", "  ")
+}
@@ -0,0 +1,71 @@
+package trace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/regrada-ai/regrada/internal/version"
+)
+
+// Merge combines sessions into one, de-duplicating calls that appear
+// in more than one input (as happens when sharded CI jobs overlap) and
+// re-indexing the result in timeline order. The merged session keeps
+// the env tag when all inputs agree and the earliest start time.
+func Merge(sessions []*Session) (*Session, error) {
+	if len(sessions) == 0 {
+		return nil, fmt.Errorf("no sessions to merge")
+	}
+	merged := &Session{
+		ID:        NewSessionID(),
+		StartedAt: sessions[0].StartedAt,
+		Env:       sessions[0].Env,
+		Labels:    map[string]string{},
+	}
+	merged.RegradaVersion = sessions[0].RegradaVersion
+	seen := map[string]bool{}
+	for _, s := range sessions {
+		if w := version.CompareWarning(merged.RegradaVersion, s.RegradaVersion); w != "" {
+			fmt.Fprintln(os.Stderr, w)
+			merged.RegradaVersion = ""
+		}
+		if s.StartedAt.Before(merged.StartedAt) {
+			merged.StartedAt = s.StartedAt
+		}
+		if s.Env != merged.Env {
+			merged.Env = ""
+		}
+		for k, v := range s.Labels {
+			merged.Labels[k] = v
+		}
+		for _, c := range s.Calls {
+			key := callKey(&c)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged.Calls = append(merged.Calls, c)
+		}
+	}
+	if len(merged.Labels) == 0 {
+		merged.Labels = nil
+	}
+	sort.SliceStable(merged.Calls, func(i, j int) bool {
+		return merged.Calls[i].StartedAt.Before(merged.Calls[j].StartedAt)
+	})
+	for i := range merged.Calls {
+		merged.Calls[i].Index = i
+	}
+	return merged, nil
+}
+
+// callKey identifies a call for de-duplication: same endpoint, same
+// request bytes, same start instant.
+func callKey(c *Call) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s %s ", c.Method, c.URL, c.StartedAt.UTC().Format("2006-01-02T15:04:05.000"))
+	h.Write(c.Request)
+	return hex.EncodeToString(h.Sum(nil))
+}
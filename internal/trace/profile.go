@@ -0,0 +1,172 @@
+package trace
+
+import (
+	"net/url"
+	"sort"
+)
+
+// Profile aggregates behavior over a set of sessions: which models and
+// tools were used and how fast calls were. It is the unit compared when
+// diffing environments.
+type Profile struct {
+	Sessions int
+	Calls    int
+	// Models counts calls per model name.
+	Models map[string]int
+	// Tools counts tool invocations per tool name.
+	Tools map[string]int
+	// Services counts calls per originating service.
+	Services map[string]int
+	// Languages counts calls per detected response language.
+	Languages map[string]int
+	// EmbeddingCalls counts embeddings calls; their token totals are
+	// tracked separately because they bill differently from
+	// completions.
+	EmbeddingCalls  int
+	EmbeddingTokens int
+	// Endpoints aggregates calls per endpoint path, so shifts in the
+	// call mix (chat vs embeddings vs moderation) are visible.
+	Endpoints map[string]*EndpointStats
+	// MeanLatencyMS is the average call duration.
+	MeanLatencyMS float64
+	// P50, P95 and P99LatencyMS are call-duration percentiles; unlike
+	// the mean they surface tail regressions that a few fast calls
+	// would otherwise hide.
+	P50LatencyMS float64
+	P95LatencyMS float64
+	P99LatencyMS float64
+	// CostUSD sums the per-call cost estimates stamped on the
+	// sessions, zero when none carry them.
+	CostUSD float64
+}
+
+// EndpointStats aggregates the calls that hit one endpoint path.
+type EndpointStats struct {
+	Calls         int
+	MeanLatencyMS float64
+	// Tokens is the total prompt plus completion tokens reported for
+	// calls to this endpoint.
+	Tokens int
+}
+
+// BuildProfile aggregates sessions into a Profile.
+func BuildProfile(sessions []*Session) *Profile {
+	p := &Profile{Models: map[string]int{}, Tools: map[string]int{}, Services: map[string]int{}, Languages: map[string]int{}, Endpoints: map[string]*EndpointStats{}}
+	var totalMS float64
+	var durations []float64
+	endpointMS := map[string]float64{}
+	for _, s := range sessions {
+		p.Sessions++
+		for _, c := range s.Calls {
+			p.Calls++
+			if c.Model != "" {
+				p.Models[c.Model]++
+			}
+			if c.Service != "" {
+				p.Services[c.Service]++
+			}
+			for _, tool := range c.ToolCalls {
+				p.Tools[tool]++
+			}
+			if c.Language != "" {
+				p.Languages[c.Language]++
+			}
+			if c.IsEmbedding() {
+				p.EmbeddingCalls++
+				p.EmbeddingTokens += c.PromptTokens
+			}
+			if ep := EndpointPath(c.URL); ep != "" {
+				es := p.Endpoints[ep]
+				if es == nil {
+					es = &EndpointStats{}
+					p.Endpoints[ep] = es
+				}
+				es.Calls++
+				es.Tokens += c.PromptTokens + c.CompletionTokens
+				endpointMS[ep] += c.DurationMS
+			}
+			totalMS += c.DurationMS
+			durations = append(durations, c.DurationMS)
+			p.CostUSD += c.CostUSD
+		}
+	}
+	for ep, es := range p.Endpoints {
+		es.MeanLatencyMS = endpointMS[ep] / float64(es.Calls)
+	}
+	if p.Calls > 0 {
+		p.MeanLatencyMS = totalMS / float64(p.Calls)
+		sort.Float64s(durations)
+		p.P50LatencyMS = LatencyPercentile(durations, 50)
+		p.P95LatencyMS = LatencyPercentile(durations, 95)
+		p.P99LatencyMS = LatencyPercentile(durations, 99)
+	}
+	return p
+}
+
+// LatencyPercentile returns the pth-percentile (0-100) of the sorted
+// durations using the nearest-rank method, 0 when there are none.
+func LatencyPercentile(sorted []float64, p int) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := (len(sorted)*p + 99) / 100
+	if idx > 0 {
+		idx--
+	}
+	return sorted[idx]
+}
+
+// EndpointPath reduces a call URL to its endpoint path, dropping host
+// and query so the same endpoint aggregates across providers'
+// per-resource hosts and parameters like api-version.
+func EndpointPath(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return u.Path
+}
+
+// SortedEndpoints returns the union of endpoint paths across stats
+// maps, sorted.
+func SortedEndpoints(maps ...map[string]*EndpointStats) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// FilterEnv returns the sessions tagged with env.
+func FilterEnv(sessions []*Session, env string) []*Session {
+	var out []*Session
+	for _, s := range sessions {
+		if s.Env == env {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// SortedKeys returns the union of keys across count maps, sorted.
+func SortedKeys(maps ...map[string]int) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
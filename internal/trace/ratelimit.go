@@ -0,0 +1,62 @@
+package trace
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// RateLimit is the quota telemetry a provider reported on one call.
+// Values are -1 when the provider did not send the header.
+type RateLimit struct {
+	LimitRequests     int `json:"limit_requests"`
+	RemainingRequests int `json:"remaining_requests"`
+	LimitTokens       int `json:"limit_tokens"`
+	RemainingTokens   int `json:"remaining_tokens"`
+}
+
+// ParseRateLimitHeaders extracts rate limit telemetry from provider
+// response headers, covering both the x-ratelimit-* (OpenAI) and
+// anthropic-ratelimit-* families. It returns nil when no rate limit
+// headers are present.
+func ParseRateLimitHeaders(h http.Header) *RateLimit {
+	rl := &RateLimit{LimitRequests: -1, RemainingRequests: -1, LimitTokens: -1, RemainingTokens: -1}
+	found := false
+	read := func(dst *int, names ...string) {
+		for _, name := range names {
+			if v := h.Get(name); v != "" {
+				if n, err := strconv.Atoi(v); err == nil {
+					*dst = n
+					found = true
+					return
+				}
+			}
+		}
+	}
+	read(&rl.LimitRequests, "x-ratelimit-limit-requests", "anthropic-ratelimit-requests-limit")
+	read(&rl.RemainingRequests, "x-ratelimit-remaining-requests", "anthropic-ratelimit-requests-remaining")
+	read(&rl.LimitTokens, "x-ratelimit-limit-tokens", "anthropic-ratelimit-tokens-limit")
+	read(&rl.RemainingTokens, "x-ratelimit-remaining-tokens", "anthropic-ratelimit-tokens-remaining")
+	if !found {
+		return nil
+	}
+	return rl
+}
+
+// RateLimitLow returns the tightest remaining-quota observations across
+// the session: the minimum remaining requests and tokens seen, or -1
+// when never reported.
+func (s *Session) RateLimitLow() (minRequests, minTokens int) {
+	minRequests, minTokens = -1, -1
+	for _, c := range s.Calls {
+		if c.RateLimit == nil {
+			continue
+		}
+		if r := c.RateLimit.RemainingRequests; r >= 0 && (minRequests < 0 || r < minRequests) {
+			minRequests = r
+		}
+		if t := c.RateLimit.RemainingTokens; t >= 0 && (minTokens < 0 || t < minTokens) {
+			minTokens = t
+		}
+	}
+	return minRequests, minTokens
+}
@@ -0,0 +1,177 @@
+// Package trace defines recorded sessions of model traffic and
+// utilities for analyzing them.
+package trace
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/regrada-ai/regrada/internal/atomicfile"
+)
+
+// SchemaVersion is the current session file schema. Files stamped
+// with a higher version are rejected instead of silently mis-parsed.
+const SchemaVersion = 1
+
+// Session is one recorded run of an application's model traffic.
+type Session struct {
+	// Schema is the schema version the file was written with, stamped
+	// on save; zero marks files from before versioning.
+	Schema    int       `json:"schema_version,omitempty"`
+	ID        string    `json:"id"`
+	StartedAt time.Time `json:"started_at"`
+	// RegradaVersion is the regrada version that recorded the session,
+	// used to warn before cross-version comparisons.
+	RegradaVersion string `json:"regrada_version,omitempty"`
+	// Env tags where the session was recorded ("staging", "prod").
+	Env    string            `json:"env,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Calls  []Call            `json:"calls"`
+}
+
+// Call is a single captured provider request/response pair.
+type Call struct {
+	Index    int    `json:"index"`
+	Provider string `json:"provider,omitempty"`
+	// Service attributes the call to the originating service when
+	// several services trace through one collector.
+	Service   string    `json:"service,omitempty"`
+	Model     string    `json:"model,omitempty"`
+	Method    string    `json:"method"`
+	URL       string    `json:"url"`
+	Status    int       `json:"status"`
+	StartedAt time.Time `json:"started_at"`
+	// DurationMS is the total time the call spent in the proxy;
+	// UpstreamMS the part spent waiting on the provider. The difference
+	// is regrada-induced overhead.
+	DurationMS float64 `json:"duration_ms"`
+	UpstreamMS float64 `json:"upstream_ms,omitempty"`
+
+	Request  json.RawMessage `json:"request,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+
+	// Endpoint is the base URL that served the call when the provider
+	// has failover endpoints configured.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Fault names the chaos fault injected into this call, if any.
+	Fault string `json:"fault,omitempty"`
+	// RateLimit holds quota telemetry from the provider's response
+	// headers, nil when none was reported.
+	RateLimit *RateLimit `json:"ratelimit,omitempty"`
+
+	// ToolCalls lists tool names the model invoked in this call.
+	ToolCalls []string `json:"tool_calls,omitempty"`
+	// PromptTokens and CompletionTokens are usage numbers reported by
+	// the provider, zero when unavailable.
+	PromptTokens     int `json:"prompt_tokens,omitempty"`
+	CompletionTokens int `json:"completion_tokens,omitempty"`
+	// CostUSD is the estimated provider cost of the call from the
+	// pricing table (config overrides included), zero when the model is
+	// unknown to it.
+	CostUSD float64 `json:"cost_usd,omitempty"`
+
+	// Language is the detected ISO 639-1 code of the response text,
+	// "" when undetermined.
+	Language string `json:"language,omitempty"`
+
+	// EmbeddingInputs and EmbeddingDims are set for embeddings calls:
+	// the number of vectors returned and their dimensionality.
+	EmbeddingInputs int `json:"embedding_inputs,omitempty"`
+	EmbeddingDims   int `json:"embedding_dims,omitempty"`
+}
+
+// IsEmbedding reports whether the call hit an embeddings endpoint.
+func (c *Call) IsEmbedding() bool { return c.EmbeddingDims > 0 }
+
+// ProxyOverheadMS returns the mean proxy-induced overhead — total
+// proxied time minus upstream time — over the n calls that recorded
+// both, so users can tell regrada overhead from provider latency.
+func (s *Session) ProxyOverheadMS() (mean float64, n int) {
+	var sum float64
+	for _, c := range s.Calls {
+		if c.UpstreamMS == 0 {
+			continue
+		}
+		sum += c.DurationMS - c.UpstreamMS
+		n++
+	}
+	if n == 0 {
+		return 0, 0
+	}
+	return sum / float64(n), n
+}
+
+// NewSessionID returns a sortable, unique session identifier.
+func NewSessionID() string {
+	var b [4]byte
+	_, _ = rand.Read(b[:])
+	return time.Now().UTC().Format("20060102-150405") + "-" + hex.EncodeToString(b[:])
+}
+
+// Load reads a session file.
+func Load(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading session: %w", err)
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if s.Schema > SchemaVersion {
+		return nil, fmt.Errorf("%s uses session schema v%d; this regrada understands up to v%d — upgrade regrada", path, s.Schema, SchemaVersion)
+	}
+	return &s, nil
+}
+
+// Save writes the session into dir as <id>.json.
+func (s *Session) Save(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	if s.Schema == 0 {
+		s.Schema = SchemaVersion
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, s.ID+".json")
+	return path, atomicfile.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// List loads every session in dir, newest first.
+func List(dir string) ([]*Session, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var sessions []*Session
+	for _, path := range matches {
+		s, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt.After(sessions[j].StartedAt)
+	})
+	return sessions, nil
+}
+
+// Find returns the session with the given id from dir.
+func Find(dir, id string) (*Session, error) {
+	path := filepath.Join(dir, id+".json")
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("no session %q in %s", id, dir)
+	}
+	return Load(path)
+}
@@ -0,0 +1,124 @@
+// Package upload pushes run artifacts to a configured destination so
+// CI jobs can link to them instead of scripting their own uploads.
+package upload
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Uploader stores artifact bytes under a key and returns a stable URL
+// for the stored object.
+type Uploader interface {
+	// Put stores data under key and returns the URL it is reachable at.
+	Put(key string, data []byte, contentType string) (string, error)
+}
+
+// Factory creates an Uploader for a destination URL.
+type Factory func(dest *url.URL) (Uploader, error)
+
+var factories = map[string]Factory{}
+
+// RegisterScheme adds an uploader factory for a URL scheme. Storage
+// backends register themselves from init.
+func RegisterScheme(scheme string, f Factory) {
+	if _, dup := factories[scheme]; dup {
+		panic("upload: duplicate scheme " + scheme)
+	}
+	factories[scheme] = f
+}
+
+// New returns an Uploader for dest, which must be a URL such as
+// https://bucket.example.com/prefix or file:///var/artifacts.
+func New(dest string) (Uploader, error) {
+	u, err := url.Parse(dest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination %q: %w", dest, err)
+	}
+	f, ok := factories[u.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("unsupported destination scheme %q", u.Scheme)
+	}
+	return f(u)
+}
+
+func init() {
+	RegisterScheme("http", newHTTPUploader)
+	RegisterScheme("https", newHTTPUploader)
+	RegisterScheme("file", newFileUploader)
+}
+
+// httpUploader PUTs objects to base/key.
+type httpUploader struct {
+	base   *url.URL
+	client *http.Client
+}
+
+func newHTTPUploader(dest *url.URL) (Uploader, error) {
+	return &httpUploader{base: dest, client: &http.Client{Timeout: 60 * time.Second}}, nil
+}
+
+func (u *httpUploader) Put(key string, data []byte, contentType string) (string, error) {
+	target := *u.base
+	target.Path = path.Join(target.Path, key)
+	req, err := http.NewRequest(http.MethodPut, target.String(), bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("uploading %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("uploading %s: %s", key, resp.Status)
+	}
+	return target.String(), nil
+}
+
+// fileUploader copies objects into a local directory, useful for
+// network shares and for testing destinations.
+type fileUploader struct {
+	dir string
+}
+
+func newFileUploader(dest *url.URL) (Uploader, error) {
+	dir := dest.Path
+	if dest.Host != "" {
+		dir = filepath.Join(dest.Host, dest.Path)
+	}
+	return &fileUploader{dir: dir}, nil
+}
+
+func (u *fileUploader) Put(key string, data []byte, _ string) (string, error) {
+	target := filepath.Join(u.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(target, data, 0o644); err != nil {
+		return "", err
+	}
+	return "file://" + filepath.ToSlash(target), nil
+}
+
+// ContentType guesses a Content-Type from the file extension.
+func ContentType(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return "application/json"
+	case ".html":
+		return "text/html; charset=utf-8"
+	case ".xml":
+		return "application/xml"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -0,0 +1,40 @@
+// Package version holds build metadata stamped into the binary via
+// ldflags:
+//
+//	go build -ldflags "-X .../internal/version.Version=v1.2.3 \
+//	  -X .../internal/version.Commit=abc123 \
+//	  -X .../internal/version.Date=2024-01-01"
+package version
+
+import "fmt"
+
+var (
+	// Version is the release version, "dev" for local builds.
+	Version = "dev"
+	// Commit is the git commit the binary was built from.
+	Commit = ""
+	// Date is the build date.
+	Date = ""
+)
+
+// String returns a human-readable version line.
+func String() string {
+	s := "regrada " + Version
+	if Commit != "" {
+		s += fmt.Sprintf(" (%s)", Commit)
+	}
+	if Date != "" {
+		s += " built " + Date
+	}
+	return s
+}
+
+// CompareWarning returns a warning message when two artifacts were
+// produced by different regrada versions (and so may use different
+// formats), or "" when they are comparable.
+func CompareWarning(a, b string) string {
+	if a == "" || b == "" || a == b {
+		return ""
+	}
+	return fmt.Sprintf("warning: comparing artifacts from different regrada versions (%s vs %s); formats may differ", a, b)
+}
@@ -0,0 +1,53 @@
+// Package webhook delivers run results to configured HTTP endpoints,
+// signed so receivers can verify the payload really came from regrada.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// deliverTimeout bounds one delivery so a hung receiver cannot stall
+// the run.
+const deliverTimeout = 10 * time.Second
+
+// SignatureHeader carries the payload signature on each delivery:
+// "sha256=" followed by the hex HMAC-SHA256 of the body under the
+// configured secret.
+const SignatureHeader = "X-Regrada-Signature"
+
+// Deliver POSTs the JSON body to url. A non-empty secret signs the
+// body into SignatureHeader; an empty one sends the payload unsigned.
+func Deliver(url string, body []byte, secret string) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(body, secret))
+	}
+	client := &http.Client{Timeout: deliverTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the hex HMAC-SHA256 of body under secret, the value
+// receivers recompute to authenticate a delivery.
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
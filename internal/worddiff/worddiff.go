@@ -0,0 +1,85 @@
+// Package worddiff computes word-level diffs between two texts: the
+// primitive behind intra-line change highlighting in the terminal and
+// HTML diff views, where a one-word drift should stand out inside an
+// otherwise unchanged response.
+package worddiff
+
+import "regexp"
+
+// Op is one run of the diff: unchanged (' '), removed ('-') or
+// added ('+') text. Whitespace between words is carried in Text so
+// concatenating the ops reproduces the inputs exactly.
+type Op struct {
+	Kind byte
+	Text string
+}
+
+// tokenPattern splits text into alternating word and whitespace
+// tokens, so the diff aligns on words but reassembles losslessly.
+var tokenPattern = regexp.MustCompile(`\S+|\s+`)
+
+// Diff returns the word-level diff from a to b, computed as a longest
+// common subsequence over word and whitespace tokens. Texts here are
+// single responses, small enough that the quadratic table is
+// irrelevant.
+func Diff(a, b string) []Op {
+	at := tokenPattern.FindAllString(a, -1)
+	bt := tokenPattern.FindAllString(b, -1)
+
+	lcs := make([][]int, len(at)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bt)+1)
+	}
+	for i := len(at) - 1; i >= 0; i-- {
+		for j := len(bt) - 1; j >= 0; j-- {
+			if at[i] == bt[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var out []Op
+	emit := func(kind byte, text string) {
+		if n := len(out); n > 0 && out[n-1].Kind == kind {
+			out[n-1].Text += text
+			return
+		}
+		out = append(out, Op{Kind: kind, Text: text})
+	}
+	i, j := 0, 0
+	for i < len(at) && j < len(bt) {
+		switch {
+		case at[i] == bt[j]:
+			emit(' ', at[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			emit('-', at[i])
+			i++
+		default:
+			emit('+', bt[j])
+			j++
+		}
+	}
+	for ; i < len(at); i++ {
+		emit('-', at[i])
+	}
+	for ; j < len(bt); j++ {
+		emit('+', bt[j])
+	}
+	return out
+}
+
+// Changed reports whether the diff contains any removal or addition.
+func Changed(ops []Op) bool {
+	for _, op := range ops {
+		if op.Kind != ' ' {
+			return true
+		}
+	}
+	return false
+}